@@ -1,10 +1,11 @@
 package api
 
 import (
-	"syscall"
+	"log"
 
 	"github.com/99designs/gqlgen/codegen"
 	"github.com/99designs/gqlgen/codegen/config"
+	"github.com/99designs/gqlgen/codegen/templates"
 	"github.com/99designs/gqlgen/plugin"
 	"github.com/99designs/gqlgen/plugin/modelgen"
 	"github.com/99designs/gqlgen/plugin/resolvergen"
@@ -13,8 +14,13 @@ import (
 )
 
 func Generate(cfg *config.Config, option ...Option) error {
-	_ = syscall.Unlink(cfg.Exec.Filename)
-	_ = syscall.Unlink(cfg.Model.Filename)
+	// a dry run binds to the code that is already there, so it has to be kept
+	dryRun := templates.DryRun != nil
+	cfg.Files = &templates.Files{}
+	if !dryRun {
+		cfg.Files.UnlinkGenerated(cfg.Exec.Filename)
+		cfg.Files.Unlink(cfg.Model.Filename)
+	}
 
 	plugins := []plugin.Plugin{
 		modelgen.New(),
@@ -25,6 +31,20 @@ func Generate(cfg *config.Config, option ...Option) error {
 		o(cfg, &plugins)
 	}
 
+	cfg.Header.PluginNames = nil
+	for _, p := range plugins {
+		cfg.Header.PluginNames = append(cfg.Header.PluginNames, p.Name())
+	}
+
+	// hashed before the plugins get to change it, so the hash only depends on what was configured
+	if cfg.Header.Config {
+		hash, err := cfg.Hash()
+		if err != nil {
+			return errors.Wrap(err, "failed to hash config")
+		}
+		cfg.Header.ConfigHash = hash
+	}
+
 	for _, p := range plugins {
 		if mut, ok := p.(plugin.ConfigMutator); ok {
 			err := mut.MutateConfig(cfg)
@@ -33,12 +53,22 @@ func Generate(cfg *config.Config, option ...Option) error {
 			}
 		}
 	}
+
 	// Merge again now that the generated models have been injected into the typemap
 	data, err := codegen.BuildData(cfg)
 	if err != nil {
 		return errors.Wrap(err, "merging failed")
 	}
 
+	for _, p := range plugins {
+		if mut, ok := p.(plugin.DataMutator); ok {
+			err := mut.MutateData(cfg, data)
+			if err != nil {
+				return errors.Wrap(err, p.Name())
+			}
+		}
+	}
+
 	if err = codegen.GenerateCode(data); err != nil {
 		return errors.Wrap(err, "generating core failed")
 	}
@@ -52,6 +82,11 @@ func Generate(cfg *config.Config, option ...Option) error {
 		}
 	}
 
+	if dryRun {
+		return nil
+	}
+	log.Printf("%d files written, %d unchanged\n", cfg.Files.Stats.Written, cfg.Files.Stats.Unchanged)
+
 	if err := validate(cfg); err != nil {
 		return errors.Wrap(err, "validation failed")
 	}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/99designs/gqlgen/codegen"
+	"github.com/99designs/gqlgen/codegen/config"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a plugin that records each hook it is called for
+type recorder struct {
+	name   string
+	events *[]string
+	hook   func(event string, data *codegen.Data)
+}
+
+func (r *recorder) Name() string {
+	return r.name
+}
+
+func (r *recorder) MutateConfig(cfg *config.Config) error {
+	*r.events = append(*r.events, r.name+".MutateConfig")
+	return nil
+}
+
+func (r *recorder) MutateData(cfg *config.Config, data *codegen.Data) error {
+	*r.events = append(*r.events, r.name+".MutateData")
+	r.hook("MutateData", data)
+	return nil
+}
+
+func (r *recorder) GenerateCode(data *codegen.Data) error {
+	*r.events = append(*r.events, r.name+".GenerateCode")
+	r.hook("GenerateCode", data)
+	return nil
+}
+
+func TestGenerateRunsDataMutators(t *testing.T) {
+	const dir = "testdata/mutators/out"
+	defer os.RemoveAll(dir)
+
+	cfg := config.DefaultConfig()
+	cfg.SchemaFilename = config.StringList{"testdata/mutators/schema.graphql"}
+	cfg.Exec = config.PackageConfig{Filename: dir + "/generated.go", Package: "out"}
+	cfg.Model = config.PackageConfig{Filename: dir + "/models_gen.go", Package: "out"}
+
+	var events []string
+	hook := func(event string, data *codegen.Data) {
+		_, err := os.Stat(cfg.Exec.Filename)
+		switch event {
+		case "MutateData":
+			require.NotNil(t, data.Objects.ByName("Query"), "data should already be built")
+			require.True(t, os.IsNotExist(err), "no code should have been generated yet")
+			data.QueryRoot.Name = "Mutated"
+		case "GenerateCode":
+			require.NoError(t, err)
+			require.Equal(t, "Mutated", data.QueryRoot.Name, "mutations should be seen by code generators")
+		}
+	}
+
+	err := Generate(cfg,
+		NoPlugins(),
+		AddPlugin(&recorder{name: "first", events: &events, hook: hook}),
+		AddPlugin(&recorder{name: "second", events: &events, hook: func(string, *codegen.Data) {}}),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"first.MutateConfig",
+		"second.MutateConfig",
+		"first.MutateData",
+		"second.MutateData",
+		"first.GenerateCode",
+		"second.GenerateCode",
+	}, events)
+}
+
+// structTagger is a plugin that changes the config from MutateConfig
+type structTagger struct{}
+
+func (structTagger) Name() string {
+	return "structTagger"
+}
+
+func (structTagger) MutateConfig(cfg *config.Config) error {
+	cfg.StructTag = "json"
+	return nil
+}
+
+func TestGenerateHashesConfigBeforeMutators(t *testing.T) {
+	const dir = "testdata/mutators/out"
+	defer os.RemoveAll(dir)
+
+	cfg := config.DefaultConfig()
+	cfg.SchemaFilename = config.StringList{"testdata/mutators/schema.graphql"}
+	cfg.Exec = config.PackageConfig{Filename: dir + "/generated.go", Package: "out"}
+	cfg.Model = config.PackageConfig{Filename: dir + "/models_gen.go", Package: "out"}
+	cfg.Header.Config = true
+
+	configured, err := cfg.Hash()
+	require.NoError(t, err)
+
+	require.NoError(t, Generate(cfg, NoPlugins(), AddPlugin(structTagger{})))
+	require.Equal(t, configured, cfg.Header.ConfigHash)
+
+	mutated, err := cfg.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, configured, mutated, "the plugin should have changed the config")
+}
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/99designs/gqlgen/api"
 	"github.com/99designs/gqlgen/codegen/config"
+	"github.com/99designs/gqlgen/codegen/templates"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
@@ -16,6 +22,7 @@ var genCmd = cli.Command{
 	Flags: []cli.Flag{
 		cli.BoolFlag{Name: "verbose, v", Usage: "show logs"},
 		cli.StringFlag{Name: "config, c", Usage: "the config filename"},
+		cli.BoolFlag{Name: "dry-run", Usage: "show which files would change, without writing them"},
 	},
 	Action: func(ctx *cli.Context) {
 		var cfg *config.Config
@@ -36,9 +43,65 @@ var genCmd = cli.Command{
 			}
 		}
 
+		if ctx.Bool("dry-run") {
+			templates.DryRun = printChange(os.Stdout)
+		}
+
 		if err = api.Generate(cfg); err != nil {
 			fmt.Fprintln(os.Stderr, err.Error())
 			os.Exit(3)
 		}
 	},
 }
+
+// printChange reports how each generated file would change compared to what is on disk, instead of writing it
+func printChange(w io.Writer) func(filename string, contents []byte) error {
+	return func(filename string, contents []byte) error {
+		existing, err := ioutil.ReadFile(filename)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, filename); err == nil {
+				filename = rel
+			}
+		}
+
+		switch {
+		case contents == nil && existing == nil:
+		case contents == nil:
+			fmt.Fprintf(w, "remove %s\n", filename)
+		case existing == nil:
+			fmt.Fprintf(w, "create %s (%d lines)\n", filename, len(lines(contents)))
+		case bytes.Equal(existing, contents):
+			fmt.Fprintf(w, "unchanged %s\n", filename)
+		default:
+			added, removed := changedLines(existing, contents)
+			fmt.Fprintf(w, "update %s (+%d -%d lines)\n", filename, added, removed)
+		}
+		return nil
+	}
+}
+
+// changedLines counts the lines that were added and removed, ignoring where in the file they moved to
+func changedLines(before []byte, after []byte) (added int, removed int) {
+	counts := map[string]int{}
+	for _, line := range lines(before) {
+		counts[line]++
+	}
+	for _, line := range lines(after) {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			added++
+		}
+	}
+	for _, n := range counts {
+		removed += n
+	}
+	return added, removed
+}
+
+func lines(b []byte) []string {
+	return strings.SplitAfter(strings.TrimSuffix(string(b), "\n"), "\n")
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/99designs/gqlgen/codegen/templates"
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser/ast"
+	"golang.org/x/tools/go/packages"
+)
+
+// autobindOutput is where autobind warnings are printed, tests swap it out
+var autobindOutput io.Writer = os.Stderr
+
+// Autobind adds a model for every schema type without one that has a go type of the same name in one of the AutoBind
+// packages. Packages are searched in the order they are listed and the first match wins. Matches in more than one
+// package are warned about, or are an error when AutoBindStrict is set.
+func (c *Config) Autobind(s *ast.Schema) error {
+	if len(c.AutoBind) == 0 {
+		return nil
+	}
+
+	loaded, err := packages.Load(&packages.Config{Mode: packages.LoadTypes}, c.AutoBind...)
+	if err != nil {
+		return errors.Wrap(err, "loading autobind packages")
+	}
+
+	byPath := map[string]*packages.Package{}
+	for _, p := range loaded {
+		if len(p.Errors) > 0 {
+			return errors.Wrap(packageLoadError(p.PkgPath, p.Errors[0]), "loading autobind")
+		}
+		byPath[p.PkgPath] = p
+	}
+
+	if c.Models == nil {
+		c.Models = TypeMap{}
+	}
+
+	var conflicts, suggestions []string
+	for _, name := range sortedTypes(s) {
+		if c.Models.UserDefined(name) || strings.HasPrefix(name, "__") {
+			continue
+		}
+
+		var matches []string
+		for _, path := range c.AutoBind {
+			p := byPath[path]
+			if p == nil {
+				return fmt.Errorf("autobind package %s was not loaded", path)
+			}
+
+			for _, goName := range []string{name, templates.ToGo(name)} {
+				if obj, ok := p.Types.Scope().Lookup(goName).(*types.TypeName); ok && obj.Exported() {
+					matches = append(matches, path+"."+goName)
+					break
+				}
+			}
+		}
+
+		if len(matches) == 0 {
+			suggestions = append(suggestions, autobindSuggestions(name, c.AutoBind, byPath)...)
+			continue
+		}
+		if len(matches) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%s matches %s, using %s", name, strings.Join(matches, " and "), matches[0]))
+		}
+		c.Models.Add(name, matches[0])
+	}
+
+	// a near miss is most likely a typo, in the schema or the go type, that would otherwise silently generate a model
+	for _, suggestion := range suggestions {
+		c.autobindWarn(suggestion)
+	}
+
+	if len(conflicts) > 0 {
+		if c.AutoBindStrict {
+			return fmt.Errorf("autobind found types in more than one package:\n%s", strings.Join(conflicts, "\n"))
+		}
+		for _, conflict := range conflicts {
+			c.autobindWarn(conflict)
+		}
+	}
+
+	return nil
+}
+
+// autobindWarn prints each warning once, Autobind runs from both modelgen and BuildData in a single generate
+func (c *Config) autobindWarn(msg string) {
+	if c.autobindWarned[msg] {
+		return
+	}
+	if c.autobindWarned == nil {
+		c.autobindWarned = map[string]bool{}
+	}
+	c.autobindWarned[msg] = true
+	fmt.Fprintln(autobindOutput, "autobind: "+msg)
+}
+
+// autobindSuggestions finds the types in the autobind packages that are close to, but not quite, a schema type name
+func autobindSuggestions(name string, paths []string, byPath map[string]*packages.Package) []string {
+	var suggestions []string
+	for _, path := range paths {
+		if match := closestMatch(name, exportedTypeNames(byPath[path].Types)); match != "" {
+			suggestions = append(suggestions, fmt.Sprintf("%s has no match, did you mean %s.%s?", name, path, match))
+		}
+	}
+	return suggestions
+}
+
+func sortedTypes(s *ast.Schema) []string {
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
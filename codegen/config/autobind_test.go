@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestAutobind(t *testing.T) {
+	const users = "github.com/99designs/gqlgen/codegen/config/testdata/autobind/users"
+	const billing = "github.com/99designs/gqlgen/codegen/config/testdata/autobind/billing"
+
+	schema, gerr := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: `
+		type Query { user: User, plan: Plan, order: Order, cart: Cart }
+		type User { name: String! }
+		type Invoice { id: ID! }
+		type Plan { name: String! }
+		type Order { id: ID! }
+		type Cart { id: ID! }
+		type Users { name: String! }
+	`})
+	require.Nil(t, gerr)
+
+	t.Run("binds the first match in package order", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.AutoBind = []string{users, billing}
+		cfg.Models = TypeMap{
+			"Order": {Model: StringList{"github.com/my/app.Order"}},
+		}
+
+		require.NoError(t, cfg.Autobind(schema))
+		require.Equal(t, StringList{users + ".User"}, cfg.Models["User"].Model)
+		require.Equal(t, StringList{users + ".Invoice"}, cfg.Models["Invoice"].Model)
+		require.Equal(t, StringList{billing + ".Plan"}, cfg.Models["Plan"].Model)
+		require.Equal(t, StringList{"github.com/my/app.Order"}, cfg.Models["Order"].Model)
+		require.False(t, cfg.Models.UserDefined("Cart"))
+	})
+
+	t.Run("strict mode rejects types found in more than one package", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.AutoBind = []string{billing, users}
+		cfg.AutoBindStrict = true
+
+		require.EqualError(t, cfg.Autobind(schema), "autobind found types in more than one package:\n"+
+			"Invoice matches "+billing+".Invoice and "+users+".Invoice, using "+billing+".Invoice")
+	})
+
+	t.Run("warnings are only printed once per config", func(t *testing.T) {
+		var out bytes.Buffer
+		autobindOutput = &out
+		defer func() { autobindOutput = os.Stderr }()
+
+		cfg := DefaultConfig()
+		cfg.AutoBind = []string{billing, users}
+
+		require.NoError(t, cfg.Autobind(schema))
+		require.NoError(t, cfg.Autobind(schema))
+		require.Equal(t, "autobind: Users has no match, did you mean "+users+".User?\n"+
+			"autobind: Invoice matches "+billing+".Invoice and "+users+".Invoice, using "+billing+".Invoice\n", out.String())
+	})
+}
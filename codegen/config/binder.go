@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"go/constant"
 	"go/token"
 	"go/types"
+	"sort"
 
 	"github.com/99designs/gqlgen/codegen/templates"
 	"github.com/99designs/gqlgen/internal/code"
@@ -21,6 +23,10 @@ type Binder struct {
 }
 
 func (c *Config) NewBinder(s *ast.Schema) (*Binder, error) {
+	if err := c.checkInputModels(s); err != nil {
+		return nil, err
+	}
+
 	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadTypes | packages.LoadSyntax}, c.Models.ReferencedPackages()...)
 	if err != nil {
 		return nil, err
@@ -29,7 +35,7 @@ func (c *Config) NewBinder(s *ast.Schema) (*Binder, error) {
 	for _, p := range pkgs {
 		for _, e := range p.Errors {
 			if e.Kind == packages.ListError {
-				return nil, p.Errors[0]
+				return nil, packageLoadError(p.PkgPath, p.Errors[0])
 			}
 		}
 	}
@@ -41,6 +47,25 @@ func (c *Config) NewBinder(s *ast.Schema) (*Binder, error) {
 	}, nil
 }
 
+// checkInputModels makes sure input_model is only used for scalars, the only types that are both inputs and outputs.
+func (c *Config) checkInputModels(s *ast.Schema) error {
+	var names []string
+	for name, entry := range c.Models {
+		if len(entry.InputModel) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def := s.Types[name]
+		if def != nil && def.Kind != ast.Scalar {
+			return fmt.Errorf("model %s: input_model can only be used for scalars, not %s", name, def.Kind)
+		}
+	}
+	return nil
+}
+
 func (b *Binder) TypePosition(typ types.Type) token.Position {
 	named, isNamed := typ.(*types.Named)
 	if !isNamed {
@@ -151,7 +176,7 @@ func (b *Binder) FindObject(pkgName string, typeName string) (types.Object, erro
 		}
 	}
 
-	return nil, errors.Errorf("unable to find type %s\n", fullName)
+	return nil, typeNotFoundError(fullName, pkg.Types, typeName)
 }
 
 func (b *Binder) PointerTo(ref *TypeReference) *TypeReference {
@@ -163,6 +188,8 @@ func (b *Binder) PointerTo(ref *TypeReference) *TypeReference {
 		Unmarshaler: ref.Unmarshaler,
 		Marshaler:   ref.Marshaler,
 		IsMarshaler: ref.IsMarshaler,
+		EnumValues:  ref.EnumValues,
+		InputOnly:   ref.InputOnly,
 	}
 
 	b.References = append(b.References, newRef)
@@ -174,10 +201,25 @@ type TypeReference struct {
 	Definition  *ast.Definition
 	GQL         *ast.Type
 	GO          types.Type
-	CastType    types.Type  // Before calling marshalling functions cast from/to this base type
-	Marshaler   *types.Func // When using external marshalling functions this will point to the Marshal function
-	Unmarshaler *types.Func // When using external marshalling functions this will point to the Unmarshal function
-	IsMarshaler bool        // Does the type implement graphql.Marshaler and graphql.Unmarshaler
+	CastType    types.Type           // Before calling marshalling functions cast from/to this base type
+	Marshaler   *types.Func          // When using external marshalling functions this will point to the Marshal function
+	Unmarshaler *types.Func          // When using external marshalling functions this will point to the Unmarshal function
+	IsMarshaler bool                 // Does the type implement graphql.Marshaler and graphql.Unmarshaler
+	EnumValues  []EnumValueReference // When binding enums to existing go types, the go value for each graphql enum value
+	InputOnly   bool                 // Is this a scalar's input_model, which is only ever unmarshaled
+}
+
+type EnumValueReference struct {
+	Definition *ast.EnumValueDefinition
+	Object     types.Object
+}
+
+// GoValue is the go expression for the bound value, qualified with its package when used from another one
+func (r EnumValueReference) GoValue() string {
+	if alias := templates.CurrentImports.Lookup(r.Object.Pkg().Path()); alias != "" {
+		return alias + "." + r.Object.Name()
+	}
+	return r.Object.Name()
 }
 
 func (ref *TypeReference) Elem() *TypeReference {
@@ -190,6 +232,8 @@ func (ref *TypeReference) Elem() *TypeReference {
 			Unmarshaler: ref.Unmarshaler,
 			Marshaler:   ref.Marshaler,
 			IsMarshaler: ref.IsMarshaler,
+			EnumValues:  ref.EnumValues,
+			InputOnly:   ref.InputOnly,
 		}
 	}
 
@@ -202,6 +246,8 @@ func (ref *TypeReference) Elem() *TypeReference {
 			Unmarshaler: ref.Unmarshaler,
 			Marshaler:   ref.Marshaler,
 			IsMarshaler: ref.IsMarshaler,
+			EnumValues:  ref.EnumValues,
+			InputOnly:   ref.InputOnly,
 		}
 	}
 	return nil
@@ -271,7 +317,7 @@ func (t *TypeReference) MarshalFunc() string {
 		panic(errors.New("Definition missing for " + t.GQL.Name()))
 	}
 
-	if t.Definition.Kind == ast.InputObject {
+	if t.Definition.Kind == ast.InputObject || t.InputOnly {
 		return ""
 	}
 
@@ -310,20 +356,59 @@ func isIntf(t types.Type) bool {
 	return ok
 }
 
-func (b *Binder) TypeReference(schemaType *ast.Type, bindTarget types.Type) (ret *TypeReference, err error) {
+func (b *Binder) TypeReference(schemaType *ast.Type, bindTarget types.Type) (*TypeReference, error) {
+	entry := b.cfg.Models[schemaType.Name()]
+	ref, err := b.typeReference(schemaType, bindTarget, entry.Model, false)
+	if err != nil && bindTarget != nil && len(entry.InputModel) > 0 {
+		if inputRef, ierr := b.typeReference(schemaType, bindTarget, entry.InputModel, true); ierr == nil && inputRef.InputOnly {
+			return nil, &InputOnlyError{Name: schemaType.Name(), GoType: bindTarget}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b.PushRef(ref)
+	return ref, nil
+}
+
+// InputTypeReference is like TypeReference, but for a type used as an input, eg an argument or an input field. It
+// binds scalars to their input_model when they have one.
+func (b *Binder) InputTypeReference(schemaType *ast.Type, bindTarget types.Type) (*TypeReference, error) {
+	entry := b.cfg.Models[schemaType.Name()]
+	if len(entry.InputModel) == 0 {
+		return b.TypeReference(schemaType, bindTarget)
+	}
+
+	ref, err := b.typeReference(schemaType, bindTarget, entry.InputModel, true)
+	if err != nil {
+		return nil, err
+	}
+
+	b.PushRef(ref)
+	return ref, nil
+}
+
+// InputOnlyError is returned by TypeReference when an output is bound to a go type that is only the input_model of a
+// scalar, and so can't be marshaled.
+type InputOnlyError struct {
+	Name   string
+	GoType types.Type
+}
+
+func (e *InputOnlyError) Error() string {
+	return fmt.Sprintf("%s is bound to %s, which is its input_model and can only be used for inputs", e.Name, e.GoType.String())
+}
+
+func (b *Binder) typeReference(schemaType *ast.Type, bindTarget types.Type, models StringList, input bool) (*TypeReference, error) {
 	var pkgName, typeName string
 	def := b.schema.Types[schemaType.Name()]
-	defer func() {
-		if err == nil && ret != nil {
-			b.PushRef(ret)
-		}
-	}()
 
-	if len(b.cfg.Models[schemaType.Name()].Model) == 0 {
+	if len(models) == 0 {
 		return nil, fmt.Errorf("%s was not found", schemaType.Name())
 	}
 
-	for _, model := range b.cfg.Models[schemaType.Name()].Model {
+	for _, model := range models {
 		if model == "map[string]interface{}" {
 			if !isMap(bindTarget) {
 				continue
@@ -354,6 +439,7 @@ func (b *Binder) TypeReference(schemaType *ast.Type, bindTarget types.Type) (ret
 		ref := &TypeReference{
 			Definition: def,
 			GQL:        schemaType,
+			InputOnly:  input && !b.cfg.Models[schemaType.Name()].Model.Has(model),
 		}
 
 		obj, err := b.FindObject(pkgName, typeName)
@@ -365,9 +451,15 @@ func (b *Binder) TypeReference(schemaType *ast.Type, bindTarget types.Type) (ret
 			ref.GO = fun.Type().(*types.Signature).Params().At(0).Type()
 			ref.Marshaler = fun
 			ref.Unmarshaler = types.NewFunc(0, fun.Pkg(), "Unmarshal"+typeName, nil)
-		} else if hasMethod(obj.Type(), "MarshalGQL") && hasMethod(obj.Type(), "UnmarshalGQL") {
+		} else if (ref.InputOnly || hasMethod(obj.Type(), "MarshalGQL")) && hasMethod(obj.Type(), "UnmarshalGQL") {
 			ref.GO = obj.Type()
 			ref.IsMarshaler = true
+		} else if len(b.cfg.Models[schemaType.Name()].EnumValues) > 0 {
+			ref.GO = obj.Type()
+			ref.EnumValues, err = b.enumValues(def, obj.Type())
+			if err != nil {
+				return nil, err
+			}
 		} else if underlying := basicUnderlying(obj.Type()); underlying != nil && underlying.Kind() == types.String {
 			// Special case for named types wrapping strings. Used by default enum implementations.
 
@@ -400,6 +492,70 @@ func (b *Binder) TypeReference(schemaType *ast.Type, bindTarget types.Type) (ret
 	return nil, fmt.Errorf("%s has type compatible with %s", schemaType.Name(), bindTarget.String())
 }
 
+// enumValues finds the go value bound to each of the graphql enum values, making sure every value is bound exactly
+// once to something of the enum's go type.
+func (b *Binder) enumValues(def *ast.Definition, typ types.Type) ([]EnumValueReference, error) {
+	if def.Kind != ast.Enum {
+		return nil, fmt.Errorf("%s has enum_values but is not an enum", def.Name)
+	}
+
+	configured := b.cfg.Models[def.Name].EnumValues
+	for name := range configured {
+		if def.EnumValues.ForName(name) == nil {
+			return nil, fmt.Errorf("%s has no enum value %s", def.Name, name)
+		}
+	}
+
+	var refs []EnumValueReference
+	for _, value := range def.EnumValues {
+		bound, ok := configured[value.Name]
+		if !ok || bound.Value == "" {
+			return nil, fmt.Errorf("%s.%s is not bound to a go value in enum_values", def.Name, value.Name)
+		}
+
+		pkgName, objName := code.PkgAndType(bound.Value)
+		if pkgName == "" {
+			return nil, fmt.Errorf("missing package name for %s.%s", def.Name, value.Name)
+		}
+
+		obj, err := b.FindObject(pkgName, objName)
+		if err != nil {
+			return nil, err
+		}
+
+		_, isConst := obj.(*types.Const)
+		_, isVar := obj.(*types.Var)
+		if !isConst && !isVar {
+			return nil, fmt.Errorf("%s.%s is bound to %s, which is not a constant or variable", def.Name, value.Name, bound.Value)
+		}
+		if !types.Identical(obj.Type(), typ) {
+			return nil, fmt.Errorf("%s.%s is bound to %s of type %s, expected %s", def.Name, value.Name, bound.Value, obj.Type().String(), typ.String())
+		}
+
+		for _, other := range refs {
+			if sameConstant(obj, other.Object) {
+				return nil, fmt.Errorf("%s.%s and %s.%s are both bound to the same value", def.Name, other.Definition.Name, def.Name, value.Name)
+			}
+		}
+
+		refs = append(refs, EnumValueReference{
+			Definition: value,
+			Object:     obj,
+		})
+	}
+
+	return refs, nil
+}
+
+func sameConstant(a types.Object, b types.Object) bool {
+	ac, aIsConst := a.(*types.Const)
+	bc, bIsConst := b.(*types.Const)
+	if !aIsConst || !bIsConst {
+		return a == b
+	}
+	return constant.Compare(ac.Val(), token.EQL, bc.Val())
+}
+
 func (b *Binder) CopyModifiersFromAst(t *ast.Type, base types.Type) types.Type {
 	if t.Elem != nil {
 		return types.NewSlice(b.CopyModifiersFromAst(t.Elem, base))
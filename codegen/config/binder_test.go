@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+func TestBindEnumValues(t *testing.T) {
+	const pkg = "github.com/99designs/gqlgen/codegen/config/testdata/enum"
+
+	bind := func(values map[string]TypeMapEnumValue) (*TypeReference, error) {
+		cfg := DefaultConfig()
+		cfg.Models = TypeMap{
+			"Status": {Model: StringList{pkg + ".Status"}, EnumValues: values},
+		}
+
+		schema, gerr := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: `
+			type Query { status: Status }
+			enum Status { ACTIVE INACTIVE }
+		`})
+		require.Nil(t, gerr)
+
+		binder, err := cfg.NewBinder(schema)
+		require.NoError(t, err)
+
+		return binder.TypeReference(ast.NonNullNamedType("Status", nil), nil)
+	}
+
+	t.Run("binds each value", func(t *testing.T) {
+		ref, err := bind(map[string]TypeMapEnumValue{
+			"ACTIVE":   {Value: pkg + ".StatusActive"},
+			"INACTIVE": {Value: pkg + ".StatusInactive"},
+		})
+		require.NoError(t, err)
+		require.Len(t, ref.EnumValues, 2)
+		require.Equal(t, "ACTIVE", ref.EnumValues[0].Definition.Name)
+		require.Equal(t, "StatusActive", ref.EnumValues[0].Object.Name())
+		require.Equal(t, "INACTIVE", ref.EnumValues[1].Definition.Name)
+		require.Equal(t, "StatusInactive", ref.EnumValues[1].Object.Name())
+	})
+
+	t.Run("every value must be bound", func(t *testing.T) {
+		_, err := bind(map[string]TypeMapEnumValue{
+			"ACTIVE": {Value: pkg + ".StatusActive"},
+		})
+		require.EqualError(t, err, "Status.INACTIVE is not bound to a go value in enum_values")
+	})
+
+	t.Run("values must exist in the schema", func(t *testing.T) {
+		_, err := bind(map[string]TypeMapEnumValue{
+			"ACTIVE":   {Value: pkg + ".StatusActive"},
+			"INACTIVE": {Value: pkg + ".StatusInactive"},
+			"DELETED":  {Value: pkg + ".StatusDisabled"},
+		})
+		require.EqualError(t, err, "Status has no enum value DELETED")
+	})
+
+	t.Run("values must have the enum type", func(t *testing.T) {
+		_, err := bind(map[string]TypeMapEnumValue{
+			"ACTIVE":   {Value: pkg + ".OtherValue"},
+			"INACTIVE": {Value: pkg + ".StatusInactive"},
+		})
+		require.EqualError(t, err, "Status.ACTIVE is bound to "+pkg+".OtherValue of type "+pkg+".Other, expected "+pkg+".Status")
+	})
+
+	t.Run("values must be distinct", func(t *testing.T) {
+		_, err := bind(map[string]TypeMapEnumValue{
+			"ACTIVE":   {Value: pkg + ".StatusDisabled"},
+			"INACTIVE": {Value: pkg + ".StatusInactive"},
+		})
+		require.EqualError(t, err, "Status.ACTIVE and Status.INACTIVE are both bound to the same value")
+	})
+}
+
+func TestCopyModifiersFromAst(t *testing.T) {
+	pkg := types.NewPackage("github.com/my/app/models", "models")
+	thing := types.NewNamed(types.NewTypeName(0, pkg, "Thing", nil), types.NewStruct(nil, nil), nil)
+
+	copyModifiers := func(schemaType string) string {
+		typ, err := parser.ParseQuery(&ast.Source{Input: "query($v: " + schemaType + ") { x }"})
+		require.Nil(t, err)
+		return (&Binder{}).CopyModifiersFromAst(typ.Operations[0].VariableDefinitions[0].Type, thing).String()
+	}
+
+	require.Equal(t, "github.com/my/app/models.Thing", copyModifiers("Thing!"))
+	require.Equal(t, "*github.com/my/app/models.Thing", copyModifiers("Thing"))
+
+	// non-null list elements are values, only nullable elements need pointers to express null
+	require.Equal(t, "[]github.com/my/app/models.Thing", copyModifiers("[Thing!]!"))
+	require.Equal(t, "[]github.com/my/app/models.Thing", copyModifiers("[Thing!]"))
+	require.Equal(t, "[]*github.com/my/app/models.Thing", copyModifiers("[Thing]!"))
+	require.Equal(t, "[][]github.com/my/app/models.Thing", copyModifiers("[[Thing!]!]!"))
+}
+
+func TestFindObjectSuggestions(t *testing.T) {
+	const pkg = "github.com/99designs/gqlgen/codegen/config/testdata/enum"
+
+	cfg := DefaultConfig()
+	cfg.Models = TypeMap{
+		"Status": {Model: StringList{pkg + ".Status"}},
+	}
+	schema, gerr := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: `
+		type Query { status: Status }
+		enum Status { ACTIVE INACTIVE }
+	`})
+	require.Nil(t, gerr)
+
+	binder, err := cfg.NewBinder(schema)
+	require.NoError(t, err)
+
+	_, err = binder.FindObject(pkg, "Stauts")
+	require.EqualError(t, err, "unable to find type "+pkg+".Stauts, did you mean Status? "+pkg+" has types Other, Status")
+
+	_, err = binder.FindObject(pkg, "Order")
+	require.EqualError(t, err, "unable to find type "+pkg+".Order, "+pkg+" has types Other, Status")
+}
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"Invoice", "Organization", "User", "UserID"}
+
+	require.Equal(t, "User", closestMatch("Usr", candidates))
+	require.Equal(t, "UserID", closestMatch("UserId", candidates))
+	require.Equal(t, "Invoice", closestMatch("Invocie", candidates))
+	require.Equal(t, "Organization", closestMatch("Organisaton", candidates))
+	require.Equal(t, "", closestMatch("Order", candidates))
+	require.Equal(t, "", closestMatch("User", []string{"User"}))
+}
+
+func TestPackageLoadError(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	gomod := filepath.Join(wd, "..", "..", "go.mod")
+
+	err = packageLoadError("github.com/acme/api/model", errors.New("cannot find package"))
+	require.EqualError(t, err, "unable to load package github.com/acme/api/model: cannot find package; its module is not in "+filepath.Clean(gomod))
+
+	err = packageLoadError("github.com/99designs/gqlgen/missing", errors.New("cannot find package"))
+	require.EqualError(t, err, "unable to load package github.com/99designs/gqlgen/missing: cannot find package; its module is in "+filepath.Clean(gomod))
+}
@@ -2,13 +2,18 @@ package config
 
 import (
 	"fmt"
+	"go/token"
 	"go/types"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/99designs/gqlgen/codegen/templates"
 	"github.com/99designs/gqlgen/internal/code"
 	"github.com/pkg/errors"
 	"github.com/vektah/gqlparser"
@@ -17,12 +22,53 @@ import (
 )
 
 type Config struct {
-	SchemaFilename StringList    `yaml:"schema,omitempty"`
-	Exec           PackageConfig `yaml:"exec"`
-	Model          PackageConfig `yaml:"model"`
-	Resolver       PackageConfig `yaml:"resolver,omitempty"`
-	Models         TypeMap       `yaml:"models,omitempty"`
-	StructTag      string        `yaml:"struct_tag,omitempty"`
+	SchemaFilename    StringList        `yaml:"schema,omitempty"`
+	SchemaInline      map[string]string `yaml:"schema_inline,omitempty"`
+	Exec              PackageConfig     `yaml:"exec"`
+	Model             PackageConfig     `yaml:"model"`
+	Resolver          PackageConfig     `yaml:"resolver,omitempty"`
+	Models            TypeMap           `yaml:"models,omitempty"`
+	StructTag         string            `yaml:"struct_tag,omitempty"`
+	FieldMatching     []FieldMatching   `yaml:"field_matching,omitempty"`
+	OmitIntrospection bool              `yaml:"omit_introspection,omitempty"`
+	JSONTags          *JSONTags         `yaml:"json_tags,omitempty"`
+	AutoBind          []string          `yaml:"autobind,omitempty"`
+	AutoBindStrict    bool              `yaml:"autobind_strict,omitempty"`
+	NullabilityStrict bool              `yaml:"nullability_strict,omitempty"`
+	Complexity        Complexity        `yaml:"complexity,omitempty"`
+	Header            Header            `yaml:"header,omitempty"`
+	TemplateDir       string            `yaml:"template_dir,omitempty"`
+	LocalPrefix       string            `yaml:"goimports_local_prefix,omitempty"`
+	SkipFormat        bool              `yaml:"skip_format,omitempty"`
+
+	// SchemaFS, if set, is read for the schema files instead of the OS filesystem, eg for a schema generated in memory.
+	// Names in SchemaFilename are relative to its root, and globs in them are matched against it when the schema is
+	// loaded.
+	SchemaFS SchemaFS `yaml:"-"`
+
+	// AdditionalSources are schema sources injected by plugins, usually from MutateConfig. They are loaded after the
+	// user's schema files.
+	AdditionalSources []*ast.Source `yaml:"-"`
+
+	// OnBuildPhase, if set, is called as each phase of codegen.BuildData finishes, so callers can see where generation
+	// time goes on large schemas.
+	OnBuildPhase func(BuildPhase) `yaml:"-"`
+
+	// Files, if set, tracks the files written while generating, api.Generate sets a new one for each run
+	Files *templates.Files `yaml:"-"`
+
+	// autobindWarned holds the autobind warnings already printed
+	autobindWarned map[string]bool
+}
+
+// BuildPhase is the timing of one phase of codegen.BuildData
+type BuildPhase struct {
+	// Name is one of load schema, autobind, bind, directives, types, introspection or references
+	Name     string
+	Duration time.Duration
+	// Count is how many things the phase worked on: schema types for load schema and types, models for autobind,
+	// directives, and type references. It is zero for the others.
+	Count int
 }
 
 var cfgFilenames = []string{".gqlgen.yml", "gqlgen.yml", "gqlgen.yaml"}
@@ -64,20 +110,13 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, errors.Wrap(err, "unable to parse config")
 	}
 
-	preGlobbing := config.SchemaFilename
-	config.SchemaFilename = StringList{}
-	for _, f := range preGlobbing {
-		matches, err := filepath.Glob(f)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to glob schema filename %s", f)
-		}
+	if err := config.expandFilenames(); err != nil {
+		return nil, err
+	}
 
-		for _, m := range matches {
-			if config.SchemaFilename.Has(m) {
-				continue
-			}
-			config.SchemaFilename = append(config.SchemaFilename, m)
-		}
+	config.SchemaFilename, err = expandSchemaFilenames(config.SchemaFilename, glob)
+	if err != nil {
+		return nil, err
 	}
 
 	return config, nil
@@ -87,16 +126,138 @@ type PackageConfig struct {
 	Filename string `yaml:"filename,omitempty"`
 	Package  string `yaml:"package,omitempty"`
 	Type     string `yaml:"type,omitempty"`
+	Layout   Layout `yaml:"layout,omitempty"`
+
+	// DirName and FilenameTemplate say where the resolver files go with the follow-schema layout
+	DirName          string `yaml:"dir,omitempty"`
+	FilenameTemplate string `yaml:"filename_template,omitempty"`
 }
 
+// Layout controls how generated code is laid out in its package
+type Layout string
+
+const (
+	// LayoutSingleFile writes all of the code into the configured filename
+	LayoutSingleFile Layout = "single-file"
+	// LayoutSplit writes each part of the exec code into its own file next to exec.filename, eg generated_object.go
+	LayoutSplit Layout = "split"
+	// LayoutFollowSchema writes resolvers into a file for each schema file, named by resolver.filename_template. Only
+	// the resolver type itself is written to resolver.filename.
+	LayoutFollowSchema Layout = "follow-schema"
+)
+
+// DefaultResolverFilenameTemplate is used for the follow-schema resolver layout when filename_template is not set.
+// {name} is replaced with the name of the schema file, without its extension.
+const DefaultResolverFilenameTemplate = "{name}.resolvers.go"
+
 type TypeMapEntry struct {
-	Model  StringList              `yaml:"model"`
-	Fields map[string]TypeMapField `yaml:"fields,omitempty"`
+	Model       StringList                  `yaml:"model"`
+	InputModel  StringList                  `yaml:"input_model,omitempty"`
+	Fields      map[string]TypeMapField     `yaml:"fields,omitempty"`
+	EnumValues  map[string]TypeMapEnumValue `yaml:"enum_values,omitempty"`
+	JSONTags    *JSONTags                   `yaml:"json_tags,omitempty"`
+	ExtraFields map[string]ModelExtraField  `yaml:"extra_fields,omitempty"`
+}
+
+// ModelExtraField is a go only field added to a generated model, keyed by its go name. It isn't part of the schema.
+type ModelExtraField struct {
+	// Type is the go type qualified by its import path, eg time.Time, *github.com/my/app/db.Key or []string
+	Type string `yaml:"type"`
+	// Tag is the struct tag, without the backquotes
+	Tag string `yaml:"tag,omitempty"`
+}
+
+func (f ModelExtraField) Check() error {
+	if f.Type == "" {
+		return fmt.Errorf("type is required")
+	}
+	if strings.Contains(f.Tag, "`") {
+		return fmt.Errorf("tag can't contain a backquote")
+	}
+	return nil
 }
 
 type TypeMapField struct {
-	Resolver  bool   `yaml:"resolver"`
-	FieldName string `yaml:"fieldName"`
+	Resolver          bool   `yaml:"resolver"`
+	FieldName         string `yaml:"fieldName"`
+	IgnoreNullability bool   `yaml:"ignoreNullability"`
+}
+
+// JSONTags controls the json struct tags put on the fields of generated models
+type JSONTags struct {
+	Naming    JSONTagNaming `yaml:"naming,omitempty"`
+	OmitEmpty bool          `yaml:"omitempty,omitempty"`
+}
+
+// JSONTagNaming is how the name in a json struct tag is derived from the graphql field name
+type JSONTagNaming string
+
+const (
+	// JSONTagNamingGraphQL uses the graphql field name as is, this is the default
+	JSONTagNamingGraphQL JSONTagNaming = "graphql"
+	// JSONTagNamingSnakeCase converts the graphql field name to snake_case, eg userId becomes user_id
+	JSONTagNamingSnakeCase JSONTagNaming = "snake_case"
+	// JSONTagNamingNone leaves out the json tag
+	JSONTagNamingNone JSONTagNaming = "none"
+)
+
+func (t *JSONTags) Check() error {
+	switch t.Naming {
+	case "", JSONTagNamingGraphQL, JSONTagNamingSnakeCase, JSONTagNamingNone:
+		return nil
+	default:
+		return fmt.Errorf("naming should be one of %s, %s or %s", JSONTagNamingGraphQL, JSONTagNamingSnakeCase, JSONTagNamingNone)
+	}
+}
+
+// JSONTagsFor returns the json tag settings for the generated model of typeName, using the model's own settings over the
+// global ones.
+func (c *Config) JSONTagsFor(typeName string) JSONTags {
+	if tags := c.Models[typeName].JSONTags; tags != nil {
+		return *tags
+	}
+	if c.JSONTags != nil {
+		return *c.JSONTags
+	}
+	return JSONTags{}
+}
+
+// FieldMatching is a way of matching a schema field to the go struct field it is bound to
+type FieldMatching string
+
+const (
+	// FieldMatchingExact matches go fields named exactly what gqlgen would generate, eg UserID for userId
+	FieldMatchingExact FieldMatching = "exact"
+	// FieldMatchingCamel matches go field names ignoring case, eg UserId for userId
+	FieldMatchingCamel FieldMatching = "camel"
+	// FieldMatchingSnake matches go field names ignoring case and underscores, eg User_Id for userId
+	FieldMatchingSnake FieldMatching = "snake"
+	// FieldMatchingJSONTag matches the name in encoding/json struct tags ignoring case and underscores, eg
+	// `json:"user_id"` for userId
+	FieldMatchingJSONTag FieldMatching = "json-tag"
+)
+
+func (m FieldMatching) Check() error {
+	switch m {
+	case FieldMatchingExact, FieldMatchingCamel, FieldMatchingSnake, FieldMatchingJSONTag:
+		return nil
+	default:
+		return fmt.Errorf("%s should be one of %s, %s, %s or %s", m, FieldMatchingExact, FieldMatchingCamel, FieldMatchingSnake, FieldMatchingJSONTag)
+	}
+}
+
+// Complexity limits which objects get an entry in the generated ComplexityRoot. By default every object does, apart
+// from introspection types and types whose name starts with an underscore, which plugins use for their own types.
+type Complexity struct {
+	// Include, if set, is the only objects given complexity roots
+	Include StringList `yaml:"include,omitempty"`
+	// Exclude are objects left out of the complexity roots
+	Exclude StringList `yaml:"exclude,omitempty"`
+}
+
+// TypeMapEnumValue binds a graphql enum value to a go constant (or variable) of the enum's model type
+type TypeMapEnumValue struct {
+	Value string `yaml:"value"`
 }
 
 type StringList []string
@@ -161,6 +322,62 @@ func (c *PackageConfig) Check() error {
 	return c.normalize()
 }
 
+func (c *PackageConfig) checkLayout(layouts ...Layout) error {
+	if c.Layout == "" {
+		return nil
+	}
+	for _, layout := range layouts {
+		if c.Layout == layout {
+			return nil
+		}
+	}
+	return fmt.Errorf("layout should be one of %s or %s", layouts[0], layouts[1])
+}
+
+// ResolverFilename is the file the resolvers for fields declared in schemaFilename are written to with the
+// follow-schema layout.
+func (c *PackageConfig) ResolverFilename(schemaFilename string) string {
+	name := strings.TrimSuffix(filepath.Base(schemaFilename), filepath.Ext(schemaFilename))
+	return filepath.Join(c.DirName, strings.Replace(c.FilenameTemplate, "{name}", name, -1))
+}
+
+// checkResolverLayout fills in the defaults for the follow-schema layout, the resolver type goes into the same
+// package as the resolver files so only one of the filename or the dir needs to be set.
+func (c *Config) checkResolverLayout() error {
+	if err := c.Resolver.checkLayout(LayoutSingleFile, LayoutFollowSchema); err != nil {
+		return errors.Wrap(err, "config.resolver")
+	}
+	if c.Resolver.Layout != LayoutFollowSchema {
+		if c.Resolver.DirName != "" || c.Resolver.FilenameTemplate != "" {
+			return fmt.Errorf("config.resolver: dir and filename_template are only supported for the %s layout", LayoutFollowSchema)
+		}
+		return nil
+	}
+
+	switch {
+	case c.Resolver.Filename == "" && c.Resolver.DirName == "":
+		return fmt.Errorf("config.resolver: %s layout needs a filename or a dir", LayoutFollowSchema)
+	case c.Resolver.Filename == "":
+		c.Resolver.Filename = filepath.Join(c.Resolver.DirName, "resolver.go")
+	case c.Resolver.DirName == "":
+		c.Resolver.DirName = filepath.Dir(c.Resolver.Filename)
+	case abs(c.Resolver.DirName) != abs(filepath.Dir(c.Resolver.Filename)):
+		return fmt.Errorf("config.resolver: filename must be in dir %s, it is in the same package as the resolvers", c.Resolver.DirName)
+	}
+
+	if c.Resolver.FilenameTemplate == "" {
+		c.Resolver.FilenameTemplate = DefaultResolverFilenameTemplate
+	}
+	if !strings.Contains(c.Resolver.FilenameTemplate, "{name}") || !strings.HasSuffix(c.Resolver.FilenameTemplate, ".go") {
+		return fmt.Errorf("config.resolver: filename_template should contain {name} and end in .go")
+	}
+	if strings.ContainsAny(c.Resolver.FilenameTemplate, "/\\") {
+		return fmt.Errorf("config.resolver: filename_template should be a filename only, use dir for the directory")
+	}
+	c.Resolver.DirName = abs(c.Resolver.DirName)
+	return nil
+}
+
 func (c *PackageConfig) Pkg() *types.Package {
 	return types.NewPackage(c.ImportPath(), c.Dir())
 }
@@ -176,9 +393,43 @@ func (c *Config) Check() error {
 	if err := c.Exec.Check(); err != nil {
 		return errors.Wrap(err, "config.exec")
 	}
+	if err := c.Exec.checkLayout(LayoutSingleFile, LayoutSplit); err != nil {
+		return errors.Wrap(err, "config.exec")
+	}
 	if err := c.Model.Check(); err != nil {
 		return errors.Wrap(err, "config.model")
 	}
+	if c.Model.Layout != "" {
+		return fmt.Errorf("layout is only supported for exec and resolver")
+	}
+	for _, pkg := range []PackageConfig{c.Exec, c.Model} {
+		if pkg.DirName != "" || pkg.FilenameTemplate != "" {
+			return fmt.Errorf("dir and filename_template are only supported for resolver")
+		}
+	}
+	if err := c.checkResolverLayout(); err != nil {
+		return err
+	}
+	if err := c.Header.Check(); err != nil {
+		return errors.Wrap(err, "config.header")
+	}
+	if c.TemplateDir != "" {
+		if info, err := os.Stat(c.TemplateDir); err != nil {
+			return errors.Wrap(err, "config.template_dir")
+		} else if !info.IsDir() {
+			return fmt.Errorf("config.template_dir: %s is not a directory", c.TemplateDir)
+		}
+	}
+	if c.JSONTags != nil {
+		if err := c.JSONTags.Check(); err != nil {
+			return errors.Wrap(err, "config.json_tags")
+		}
+	}
+	for _, matching := range c.FieldMatching {
+		if err := matching.Check(); err != nil {
+			return errors.Wrap(err, "config.field_matching")
+		}
+	}
 	if c.Resolver.IsDefined() {
 		if err := c.Resolver.Check(); err != nil {
 			return errors.Wrap(err, "config.resolver")
@@ -233,15 +484,52 @@ func (tm TypeMap) Check() error {
 				return fmt.Errorf("model %s: invalid type specifier \"%s\" - you need to specify a struct to map to", typeName, entry.Model)
 			}
 		}
+		for _, model := range entry.InputModel {
+			if strings.LastIndex(model, ".") < strings.LastIndex(model, "/") {
+				return fmt.Errorf("model %s: invalid input_model type specifier \"%s\" - you need to specify a type to map to", typeName, entry.InputModel)
+			}
+		}
+		if len(entry.InputModel) > 0 && len(entry.Model) == 0 {
+			return fmt.Errorf("model %s: input_model also needs a model to use for outputs", typeName)
+		}
+		if entry.JSONTags != nil {
+			if err := entry.JSONTags.Check(); err != nil {
+				return errors.Wrapf(err, "model %s: json_tags", typeName)
+			}
+		}
+		for name, field := range entry.ExtraFields {
+			if !isGoIdentifier(name) {
+				return fmt.Errorf("model %s: extra_fields: %s is not a valid go field name", typeName, name)
+			}
+			if err := field.Check(); err != nil {
+				return errors.Wrapf(err, "model %s: extra_fields: %s", typeName, name)
+			}
+		}
 	}
 	return nil
 }
 
+// isGoIdentifier reports whether name can be used as a go identifier, ie it is made of letters, digits and
+// underscores, doesn't start with a digit and isn't a keyword
+func isGoIdentifier(name string) bool {
+	if name == "" || token.Lookup(name).IsKeyword() {
+		return false
+	}
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
 func (tm TypeMap) ReferencedPackages() []string {
 	var pkgs []string
 
 	for _, typ := range tm {
-		for _, model := range typ.Model {
+		models := append(StringList{}, typ.Model...)
+		models = append(models, typ.InputModel...)
+		for _, model := range models {
 			if model == "map[string]interface{}" || model == "interface{}" {
 				continue
 			}
@@ -330,6 +618,10 @@ func (c *Config) normalize() error {
 }
 
 func (c *Config) InjectBuiltins(s *ast.Schema) {
+	if c.Models == nil {
+		c.Models = TypeMap{}
+	}
+
 	builtins := TypeMap{
 		"__Directive":         {Model: StringList{"github.com/99designs/gqlgen/graphql/introspection.Directive"}},
 		"__DirectiveLocation": {Model: StringList{"github.com/99designs/gqlgen/graphql.String"}},
@@ -375,28 +667,97 @@ func (c *Config) InjectBuiltins(s *ast.Schema) {
 }
 
 func (c *Config) LoadSchema() (*ast.Schema, map[string]string, error) {
+	schema, sources, err := c.LoadSchemaSources()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	schemaStrings := map[string]string{}
+	for _, source := range sources {
+		schemaStrings[source.Name] = source.Input
+	}
+	return schema, schemaStrings, nil
+}
 
+// LoadSchemaSources is like LoadSchema, but returns the sources exactly as they were given to the parser, in the order
+// they were loaded.
+func (c *Config) LoadSchemaSources() (*ast.Schema, []*ast.Source, error) {
 	var sources []*ast.Source
 
-	for _, filename := range c.SchemaFilename {
-		filename = filepath.ToSlash(filename)
+	filenames := c.SchemaFilename
+	if c.SchemaFS != nil {
+		// names in an fs.FS can't start with ./, so they are cleaned before matching, which also dedupes them
+		cleaned := make(StringList, len(c.SchemaFilename))
+		for i, filename := range c.SchemaFilename {
+			cleaned[i] = path.Clean(filepath.ToSlash(filename))
+		}
+
 		var err error
-		var schemaRaw []byte
-		schemaRaw, err = ioutil.ReadFile(filename)
+		filenames, err = expandSchemaFilenames(cleaned, c.SchemaFS.Glob)
 		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, filename := range filenames {
+		filename = filepath.ToSlash(filename)
+		schemaRaw, err := c.readSchemaFile(filename)
+		if err != nil {
+			if c.SchemaFS != nil {
+				return nil, nil, errors.Wrap(err, "unable to open schema")
+			}
 			fmt.Fprintln(os.Stderr, "unable to open schema: "+err.Error())
 			os.Exit(1)
 		}
-		schemaStrings[filename] = string(schemaRaw)
-		sources = append(sources, &ast.Source{Name: filename, Input: schemaStrings[filename]})
+		sources = append(sources, &ast.Source{Name: filename, Input: string(schemaRaw)})
 	}
 
-	schema, err := gqlparser.LoadSchema(sources...)
+	inline, err := c.inlineSources()
 	if err != nil {
 		return nil, nil, err
 	}
-	return schema, schemaStrings, nil
+	sources = append(sources, inline...)
+
+	additional, err := c.dedupeAdditionalSources(sources)
+	if err != nil {
+		return nil, nil, err
+	}
+	sources = append(sources, additional...)
+
+	if err := checkUnionMembers(sources); err != nil {
+		return nil, nil, err
+	}
+
+	schema, gerr := gqlparser.LoadSchema(sources...)
+	if gerr != nil {
+		return nil, nil, gerr
+	}
+	return schema, sources, nil
+}
+
+func (c *Config) readSchemaFile(filename string) ([]byte, error) {
+	if c.SchemaFS != nil {
+		return c.SchemaFS.ReadFile(filename)
+	}
+	return ioutil.ReadFile(filename)
+}
+
+// inlineSources turns SchemaInline into sources named after their keys, sorted so they always load in the same order.
+func (c *Config) inlineSources() ([]*ast.Source, error) {
+	names := make([]string, 0, len(c.SchemaInline))
+	for name := range c.SchemaInline {
+		if c.SchemaFilename.Has(name) {
+			return nil, fmt.Errorf("schema_inline: %s is already the name of a schema file", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sources := make([]*ast.Source, len(names))
+	for i, name := range names {
+		sources[i] = &ast.Source{Name: name, Input: c.SchemaInline[name]}
+	}
+	return sources, nil
 }
 
 func abs(path string) string {
@@ -1,12 +1,17 @@
 package config
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -26,6 +31,62 @@ func TestLoadConfig(t *testing.T) {
 	})
 }
 
+func TestLoadConfigSchemaGlobs(t *testing.T) {
+	testDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(testDir)
+
+	require.NoError(t, os.Chdir(filepath.Join(testDir, "testdata", "glob")))
+
+	t.Run("expands ** in sorted order", func(t *testing.T) {
+		cfg, err := LoadConfig("gqlgen.yml")
+		require.NoError(t, err)
+		require.Equal(t, StringList{
+			"graph/schema.graphql",
+			"graph/billing/invoice.graphql",
+			"graph/users/admin/admin.graphql",
+			"graph/users/user.graphql",
+		}, cfg.SchemaFilename)
+	})
+
+	t.Run("pattern that matches nothing", func(t *testing.T) {
+		_, err := LoadConfig("nomatch.yml")
+		require.EqualError(t, err, "schema filename graph/**/*.gql did not match any files")
+	})
+}
+
+func TestLoadConfigEnv(t *testing.T) {
+	os.Setenv("GQLGEN_TEST_SCHEMA_DIR", "testdata/inline")
+	os.Setenv("GQLGEN_TEST_OUT", "graph")
+	defer os.Unsetenv("GQLGEN_TEST_SCHEMA_DIR")
+	defer os.Unsetenv("GQLGEN_TEST_OUT")
+
+	t.Run("filenames are expanded", func(t *testing.T) {
+		cfg, err := LoadConfig("testdata/env/gqlgen.yml")
+		require.NoError(t, err)
+		require.Equal(t, StringList{"testdata/inline/schema.graphql"}, cfg.SchemaFilename)
+		require.Equal(t, "graph/generated.go", cfg.Exec.Filename)
+		require.Equal(t, "graph/models_$gen.go", cfg.Model.Filename)
+	})
+
+	t.Run("unset variables are an error", func(t *testing.T) {
+		_, err := LoadConfig("testdata/env/unset.yml")
+		require.EqualError(t, err, "exec.filename: environment variable GQLGEN_TEST_UNSET is not set")
+	})
+
+	t.Run("bad references", func(t *testing.T) {
+		_, err := expandEnv("schema", "${GQLGEN_TEST_OUT")
+		require.EqualError(t, err, "schema: unterminated ${ in ${GQLGEN_TEST_OUT")
+
+		_, err = expandEnv("schema", "${1}")
+		require.EqualError(t, err, `schema: "1" is not a valid environment variable name`)
+
+		expanded, err := expandEnv("schema", "$1/$")
+		require.NoError(t, err)
+		require.Equal(t, "$1/$", expanded)
+	})
+}
+
 func TestLoadDefaultConfig(t *testing.T) {
 	testDir, err := os.Getwd()
 	require.NoError(t, err)
@@ -90,4 +151,482 @@ func TestConfigCheck(t *testing.T) {
 		err = config.Check()
 		require.EqualError(t, err, "filenames exec.go and models.go are in the same directory but have different package definitions")
 	})
+
+	t.Run("unknown exec layout", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Exec.Layout = "follow-schema"
+
+		err := config.Check()
+		require.EqualError(t, err, "config.exec: layout should be one of single-file or split")
+	})
+
+	t.Run("layout on models", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Model.Layout = LayoutSplit
+
+		err := config.Check()
+		require.EqualError(t, err, "layout is only supported for exec and resolver")
+	})
+
+	t.Run("unknown resolver layout", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Resolver = PackageConfig{Filename: "resolver.go", Layout: LayoutSplit}
+
+		err := config.Check()
+		require.EqualError(t, err, "config.resolver: layout should be one of single-file or follow-schema")
+	})
+
+	t.Run("follow-schema resolver layout defaults", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Resolver = PackageConfig{DirName: "graph", Layout: LayoutFollowSchema}
+
+		require.NoError(t, config.Check())
+		require.Equal(t, abs("graph/resolver.go"), config.Resolver.Filename)
+		require.Equal(t, abs("graph/user.resolvers.go"), config.Resolver.ResolverFilename("schema/user.graphql"))
+
+		config = DefaultConfig()
+		config.Resolver = PackageConfig{Filename: "graph/resolver.go", FilenameTemplate: "{name}_resolvers.go", Layout: LayoutFollowSchema}
+
+		require.NoError(t, config.Check())
+		require.Equal(t, abs("graph/user_resolvers.go"), config.Resolver.ResolverFilename("user.graphqls"))
+	})
+
+	t.Run("follow-schema resolver layout errors", func(t *testing.T) {
+		check := func(resolver PackageConfig) error {
+			config := DefaultConfig()
+			config.Resolver = resolver
+			return config.Check()
+		}
+
+		err := check(PackageConfig{Filename: "resolver.go", DirName: "graph"})
+		require.EqualError(t, err, "config.resolver: dir and filename_template are only supported for the follow-schema layout")
+
+		err = check(PackageConfig{Filename: "resolver.go", DirName: "graph", Layout: LayoutFollowSchema})
+		require.EqualError(t, err, "config.resolver: filename must be in dir graph, it is in the same package as the resolvers")
+
+		err = check(PackageConfig{DirName: "graph", FilenameTemplate: "resolvers.go", Layout: LayoutFollowSchema})
+		require.EqualError(t, err, "config.resolver: filename_template should contain {name} and end in .go")
+
+		err = check(PackageConfig{Layout: LayoutFollowSchema})
+		require.EqualError(t, err, "config.resolver: follow-schema layout needs a filename or a dir")
+	})
+
+	t.Run("extra field names must be go identifiers", func(t *testing.T) {
+		check := func(name string) error {
+			config := DefaultConfig()
+			config.Models = TypeMap{"User": {ExtraFields: map[string]ModelExtraField{name: {Type: "string"}}}}
+			return config.Check()
+		}
+
+		require.NoError(t, check("LoadedAt"))
+		require.NoError(t, check("_cache2"))
+		require.NoError(t, check("ÜberKey"))
+		for _, name := range []string{"", "2fast", "db-key", "type", "loaded at"} {
+			require.EqualError(t, check(name), "config.models: model User: extra_fields: "+name+" is not a valid go field name", name)
+		}
+	})
+}
+
+func TestCheckTemplateDir(t *testing.T) {
+	config := DefaultConfig()
+	config.TemplateDir = "testdata"
+	require.NoError(t, config.Check())
+
+	config.TemplateDir = "testdata/missing"
+	require.EqualError(t, config.Check(), "config.template_dir: stat testdata/missing: no such file or directory")
+
+	config.TemplateDir = "config.go"
+	require.EqualError(t, config.Check(), "config.template_dir: config.go is not a directory")
+}
+
+func TestConfigHash(t *testing.T) {
+	hash := func(configure func(cfg *Config)) string {
+		cfg := DefaultConfig()
+		configure(cfg)
+		h, err := cfg.Hash()
+		require.NoError(t, err)
+		return h
+	}
+
+	generated := hash(func(cfg *Config) {})
+	require.Len(t, generated, 12)
+	require.Equal(t, generated, hash(func(cfg *Config) {}))
+	require.Equal(t, generated, hash(func(cfg *Config) {
+		cfg.Exec.Filename = abs(cfg.Exec.Filename)
+		cfg.Model.Filename = abs(cfg.Model.Filename)
+	}), "filenames are hashed relative to the working directory")
+	require.NotEqual(t, generated, hash(func(cfg *Config) { cfg.StructTag = "json" }))
+	require.NotEqual(t, generated, hash(func(cfg *Config) { cfg.Models = TypeMap{"User": {Model: StringList{"github.com/my/app.User"}}} }))
+}
+
+func TestConfigHashWorkingDirectory(t *testing.T) {
+	testDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(testDir)
+
+	// the same project checked out in two places, and generated from the root of one and a subdir of the other
+	hash := func(subdir string) string {
+		root, err := ioutil.TempDir("", "gqlgen")
+		require.NoError(t, err)
+		defer os.RemoveAll(root)
+
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "graph", "model"), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(root, "gqlgen.yml"), []byte(`
+schema: graph/schema.graphql
+exec:
+  filename: graph/generated.go
+model:
+  filename: graph/model/models_gen.go
+resolver:
+  dir: graph
+  layout: follow-schema
+template_dir: graph
+`), 0644))
+
+		require.NoError(t, os.Chdir(filepath.Join(root, subdir)))
+		cfg, err := LoadConfigFromDefaultLocations()
+		require.NoError(t, err)
+		require.NoError(t, cfg.Check())
+
+		h, err := cfg.Hash()
+		require.NoError(t, err)
+		return h
+	}
+
+	require.Equal(t, hash("."), hash("graph/model"))
+}
+
+func TestHeader(t *testing.T) {
+	require.Equal(t, "", Header{}.String())
+	require.Equal(t, "// Copyright Acme\n", Header{Lines: []string{"Copyright Acme"}}.String())
+	require.Equal(t, "// +build !codeanalysis\n", Header{BuildConstraint: "!codeanalysis"}.String())
+	require.Equal(t, "// generated by gqlgen with modelgen\n", Header{Plugins: true, PluginNames: []string{"modelgen"}}.String())
+	require.Equal(t, "// generated by gqlgen from config 0123456789ab\n", Header{Config: true, ConfigHash: "0123456789ab"}.String())
+
+	require.NoError(t, Header{Lines: []string{"a"}, BuildConstraint: "linux,!codeanalysis darwin go1.11"}.Check())
+	require.EqualError(t, Header{Lines: []string{"a\nb"}}.Check(), "lines can't contain newlines, give each line separately")
+	require.EqualError(t, Header{BuildConstraint: "linux && !codeanalysis"}.Check(), `build_constraint "linux && !codeanalysis" is not valid: unexpected '&' in &&`)
+	require.EqualError(t, Header{BuildConstraint: "linux,"}.Check(), `build_constraint "linux," is not valid: empty term in linux,`)
+	require.EqualError(t, Header{BuildConstraint: "!!linux"}.Check(), `build_constraint "!!linux" is not valid: unexpected '!' in !!linux`)
+}
+
+func TestLoadSchemaSources(t *testing.T) {
+	load := func(additional string) (*ast.Schema, []*ast.Source, error) {
+		cfg := DefaultConfig()
+		cfg.SchemaFilename = StringList{"testdata/sources/schema.graphql"}
+		cfg.AdditionalSources = []*ast.Source{{Name: "plugin.graphql", Input: additional}}
+		return cfg.LoadSchemaSources()
+	}
+
+	t.Run("identical declarations are removed from the additional source", func(t *testing.T) {
+		schema, sources, err := load(`
+			"a moment in time"
+			scalar Time
+
+			type Post {
+				title: String! @auth(role: "reader")
+			}
+
+			directive @auth(role: String!) on FIELD_DEFINITION
+		`)
+		require.NoError(t, err)
+		require.NotNil(t, schema.Types["Post"])
+
+		require.Len(t, sources, 2)
+		require.Equal(t, "plugin.graphql", sources[1].Name)
+		require.NotContains(t, sources[1].Input, "Time")
+		require.NotContains(t, sources[1].Input, "directive")
+		require.Contains(t, sources[1].Input, `title: String! @auth(role: "reader")`)
+	})
+
+	t.Run("different declarations are reported against the user schema", func(t *testing.T) {
+		_, _, err := load(`
+			scalar Time
+
+			directive @auth(role: String) on FIELD_DEFINITION
+		`)
+		require.EqualError(t, err, "plugin.graphql:2: type Time was added to the schema by a plugin, but is already declared differently at testdata/sources/schema.graphql:6\n"+
+			"plugin.graphql:4: directive @auth was added to the schema by a plugin, but is already declared differently at testdata/sources/schema.graphql:13")
+	})
+
+	t.Run("sources without duplicates are loaded as is", func(t *testing.T) {
+		input := "extend type User {\n    age: Int\n}\n"
+		schema, sources, err := load(input)
+		require.NoError(t, err)
+		require.NotNil(t, schema.Types["User"].Fields.ForName("age"))
+		require.Equal(t, input, sources[1].Input)
+	})
+}
+
+func TestLoadSchemaInline(t *testing.T) {
+	t.Run("inline sources load after the schema files", func(t *testing.T) {
+		cfg, err := LoadConfig("testdata/inline/gqlgen.yml")
+		require.NoError(t, err)
+
+		schema, sources, err := cfg.LoadSchemaSources()
+		require.NoError(t, err)
+		require.NotNil(t, schema.Types["Time"])
+		require.NotNil(t, schema.Directives["auth"])
+
+		require.Len(t, sources, 3)
+		require.Equal(t, "testdata/inline/schema.graphql", sources[0].Name)
+		require.Equal(t, "directives.graphql", sources[1].Name)
+		require.Equal(t, "scalars.graphql", sources[2].Name)
+		require.Equal(t, "scalar Time\n", sources[2].Input)
+	})
+
+	t.Run("errors are reported against the inline name", func(t *testing.T) {
+		cfg, err := LoadConfig("testdata/inline/broken.yml")
+		require.NoError(t, err)
+
+		_, _, err = cfg.LoadSchemaSources()
+		require.EqualError(t, err, "scalars.graphql:4: Undefined type Missing.")
+	})
+
+	t.Run("schema files are read from SchemaFS", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SchemaFS = mapFS{
+			"graph/schema.graphql":    "type Query { user: User }",
+			"graph/user/user.graphql": "type User { name: String! }",
+			"graph/user/notes.txt":    "not a schema",
+		}
+		cfg.SchemaFilename = StringList{"./graph/schema.graphql", "graph/**/*.graphql"}
+
+		schema, sources, err := cfg.LoadSchemaSources()
+		require.NoError(t, err)
+		require.NotNil(t, schema.Types["User"])
+		require.Len(t, sources, 2)
+		require.Equal(t, "graph/schema.graphql", sources[0].Name)
+		require.Equal(t, "graph/user/user.graphql", sources[1].Name)
+
+		cfg.SchemaFilename = StringList{"graph/missing.graphql"}
+		_, _, err = cfg.LoadSchemaSources()
+		require.EqualError(t, err, "unable to open schema: open graph/missing.graphql: file does not exist")
+
+		cfg.SchemaFilename = StringList{"graph/*.gql"}
+		_, _, err = cfg.LoadSchemaSources()
+		require.EqualError(t, err, "schema filename graph/*.gql did not match any files")
+	})
+
+	t.Run("inline names can't shadow schema files", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SchemaFilename = StringList{"testdata/inline/schema.graphql"}
+		cfg.SchemaInline = map[string]string{"testdata/inline/schema.graphql": "scalar Time"}
+
+		_, _, err := cfg.LoadSchemaSources()
+		require.EqualError(t, err, "schema_inline: testdata/inline/schema.graphql is already the name of a schema file")
+	})
+}
+
+func TestWithoutDirective(t *testing.T) {
+	input, err := WithoutDirective(&ast.Source{Name: "schema.graphql", Input: `type User {
+    id: ID! @goField(forceResolver: true) @auth(roles: ["admin"])
+    name(format: String @goField): String @goField(
+        forceResolver: false
+    )
+}
+
+directive @goField(forceResolver: Boolean) on FIELD_DEFINITION | ARGUMENT_DEFINITION
+directive @auth(roles: [String!]) on FIELD_DEFINITION
+`}, "goField")
+	require.NoError(t, err)
+	require.Equal(t, `type User {
+    id: ID! @auth(roles: ["admin"])
+    name(format: String): String
+}
+
+directive @auth(roles: [String!]) on FIELD_DEFINITION
+`, input)
+}
+
+func TestWithoutDirectiveAdversarial(t *testing.T) {
+	const definition = "directive @goField(forceResolver: Boolean, name: String) on FIELD_DEFINITION\n"
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "descriptions mentioning the directive are kept",
+			input: `"uses @goField(forceResolver: true) to force a resolver"
+type User {
+    "a @goField in a string"
+    id: ID! @goField(forceResolver: true)
+}
+`,
+			expected: `"uses @goField(forceResolver: true) to force a resolver"
+type User {
+    "a @goField in a string"
+    id: ID!
+}
+`,
+		},
+		{
+			name: "block strings with directives, braces and parens are kept",
+			input: `"""
+Users are { "resolved" } by @goField(forceResolver: true) )
+"""
+type User {
+    """
+    type Fake { x: Int @goField }
+    """
+    id: ID! @goField(forceResolver: true)
+}
+`,
+			expected: `"""
+Users are { "resolved" } by @goField(forceResolver: true) )
+"""
+type User {
+    """
+    type Fake { x: Int @goField }
+    """
+    id: ID!
+}
+`,
+		},
+		{
+			name: "usages in type extensions are removed, and comments kept",
+			input: `type User {
+    id: ID!
+}
+
+extend type User {
+    # @goField( in a comment
+    orders: Int! @goField(forceResolver: true)
+}
+`,
+			expected: `type User {
+    id: ID!
+}
+
+extend type User {
+    # @goField( in a comment
+    orders: Int!
+}
+`,
+		},
+		{
+			name: "arguments containing parens are removed whole",
+			input: `type User {
+    id: ID! @goField(name: "id)(") @deprecated(reason: "use (uuid)")
+    name: String @goField(
+        name: ")"
+        forceResolver: true
+    ) @deprecated
+}
+`,
+			expected: `type User {
+    id: ID! @deprecated(reason: "use (uuid)")
+    name: String @deprecated
+}
+`,
+		},
+		{
+			name: "directives with a longer name are kept",
+			input: `type User {
+    id: ID! @goFieldExtra @goField
+}
+
+directive @goFieldExtra on FIELD_DEFINITION
+`,
+			expected: `type User {
+    id: ID! @goFieldExtra
+}
+
+directive @goFieldExtra on FIELD_DEFINITION
+`,
+		},
+		{
+			name: "described definitions are removed along with the description",
+			input: `"""
+Only read by gqlgen, see directive @goField
+"""
+directive @goField(
+    "(forces) a resolver"
+    forceResolver: Boolean
+    name: String = "type"
+) on FIELD_DEFINITION | INPUT_FIELD_DEFINITION
+
+type User {
+    id: ID!
+}
+`,
+			expected: `type User {
+    id: ID!
+}
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			input := tc.input
+			if !strings.Contains(input, "directive @goField(") {
+				input += "\n" + definition
+			}
+			expected := tc.expected
+			if !strings.HasSuffix(expected, "\n") {
+				expected += "\n"
+			}
+
+			stripped, err := WithoutDirective(&ast.Source{Name: "schema.graphql", Input: input}, "goField")
+			require.NoError(t, err)
+			require.Equal(t, expected, strings.TrimRight(stripped, "\n")+"\n")
+
+			_, perr := parser.ParseSchema(&ast.Source{Name: "schema.graphql", Input: stripped})
+			require.Nil(t, perr)
+		})
+	}
+}
+
+func TestJSONTagsFor(t *testing.T) {
+	cfg := DefaultConfig()
+	require.Equal(t, JSONTags{}, cfg.JSONTagsFor("User"))
+
+	cfg.JSONTags = &JSONTags{Naming: JSONTagNamingSnakeCase}
+	cfg.Models = TypeMap{
+		"Audit": {JSONTags: &JSONTags{OmitEmpty: true}},
+	}
+	require.Equal(t, JSONTags{Naming: JSONTagNamingSnakeCase}, cfg.JSONTagsFor("User"))
+	require.Equal(t, JSONTags{OmitEmpty: true}, cfg.JSONTagsFor("Audit"))
+
+	cfg.Models["Audit"] = TypeMapEntry{JSONTags: &JSONTags{Naming: "kebab"}}
+	require.EqualError(t, cfg.Check(), "config.models: model Audit: json_tags: naming should be one of graphql, snake_case or none")
+}
+
+func TestLoadSchemaUnionMembers(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SchemaFilename = StringList{"testdata/unions/schema.graphql"}
+
+	_, _, err := cfg.LoadSchemaSources()
+	require.EqualError(t, err, "testdata/unions/schema.graphql:13: union SearchResult member Node must be an object type, but is an interface declared at testdata/unions/schema.graphql:5\n"+
+		"testdata/unions/schema.graphql:15: union SearchResult member String must be an object type, but is a scalar declared at prelude.graphql:10\n"+
+		"testdata/unions/schema.graphql:15: union SearchResult member Missing is not defined")
+}
+
+// mapFS is an in memory SchemaFS
+type mapFS map[string]string
+
+func (m mapFS) ReadFile(name string) ([]byte, error) {
+	contents, ok := m[name]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return []byte(contents), nil
+}
+
+func (m mapFS) Glob(pattern string) ([]string, error) {
+	var matches []string
+	for name := range m {
+		ok, err := MatchGlob(pattern, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matches = append(matches, name)
+		}
+	}
+	sort.Strings(matches)
+	return matches, nil
 }
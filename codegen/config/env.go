@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// expandFilenames expands environment variables in the schema and output filenames of the config, see expandEnv.
+func (c *Config) expandFilenames() error {
+	for i, filename := range c.SchemaFilename {
+		expanded, err := expandEnv("schema", filename)
+		if err != nil {
+			return err
+		}
+		c.SchemaFilename[i] = expanded
+	}
+
+	packages := []struct {
+		key string
+		pkg *PackageConfig
+	}{
+		{"exec.filename", &c.Exec},
+		{"model.filename", &c.Model},
+		{"resolver.filename", &c.Resolver},
+	}
+	for _, p := range packages {
+		expanded, err := expandEnv(p.key, p.pkg.Filename)
+		if err != nil {
+			return err
+		}
+		p.pkg.Filename = expanded
+	}
+	return nil
+}
+
+// expandEnv replaces $VAR and ${VAR} in value with the environment variable, and $$ with a literal $. It is an error for
+// a variable to be unset, rather than it silently becoming empty and writing files somewhere unexpected.
+func expandEnv(key string, value string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+
+		var name string
+		switch next := value[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+			continue
+		case next == '{':
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("%s: unterminated ${ in %s", key, value)
+			}
+			name = value[i+2 : i+2+end]
+			if !isEnvName(name) {
+				return "", fmt.Errorf("%s: %q is not a valid environment variable name", key, name)
+			}
+			i += end + 2
+		case isEnvNameStart(next):
+			end := i + 2
+			for end < len(value) && (isEnvNameStart(value[end]) || value[end] >= '0' && value[end] <= '9') {
+				end++
+			}
+			name = value[i+1 : end]
+			i = end - 1
+		default:
+			b.WriteByte('$')
+			continue
+		}
+
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("%s: environment variable %s is not set", key, name)
+		}
+		b.WriteString(env)
+	}
+	return b.String(), nil
+}
+
+func isEnvName(name string) bool {
+	if name == "" || !isEnvNameStart(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isEnvNameStart(name[i]) && (name[i] < '0' || name[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isEnvNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
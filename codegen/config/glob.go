@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// expandSchemaFilenames replaces every glob pattern in filenames with the files it matches, sorted so generation is
+// stable. Patterns may use ** to match any number of directories. Plain filenames are kept as is so a missing file is
+// reported when the schema is loaded, and it is an error for a pattern to match nothing.
+func expandSchemaFilenames(filenames StringList, glob func(pattern string) ([]string, error)) (StringList, error) {
+	expanded := StringList{}
+	for _, pattern := range filenames {
+		if !isGlob(pattern) {
+			if !expanded.Has(pattern) {
+				expanded = append(expanded, pattern)
+			}
+			continue
+		}
+
+		matches, err := glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to glob schema filename %s", pattern)
+		}
+		if len(matches) == 0 {
+			return nil, errors.Errorf("schema filename %s did not match any files", pattern)
+		}
+
+		for _, m := range matches {
+			if !expanded.Has(m) {
+				expanded = append(expanded, m)
+			}
+		}
+	}
+	return expanded, nil
+}
+
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// glob is like filepath.Glob, but a path segment of ** matches zero or more directories. Only files are matched by
+// patterns that use **.
+func glob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(pattern)
+	}
+
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	for _, segment := range segments {
+		if strings.Contains(segment, "**") && segment != "**" {
+			return nil, errors.Errorf("** must be a whole path segment")
+		}
+		if _, err := filepath.Match(segment, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// walk from the deepest directory that doesn't need matching
+	var root []string
+	for len(segments) > 1 && !isGlob(segments[0]) {
+		root = append(root, segments[0])
+		segments = segments[1:]
+	}
+	rootDir := strings.Join(root, "/")
+	if rootDir == "" && len(root) > 0 {
+		rootDir = "/"
+	}
+	walkDir := rootDir
+	if walkDir == "" {
+		walkDir = "."
+	}
+
+	var matches []string
+	err := filepath.Walk(walkDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == walkDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(walkDir, path)
+		if err != nil {
+			return err
+		}
+		if matchSegments(segments, strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// matchSegments reports whether the path segments match the pattern segments, with ** matching any number of them.
+func matchSegments(pattern []string, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(path) == 0 {
+			return false
+		}
+		if ok, _ := filepath.Match(pattern[0], path[0]); !ok {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
+
+// SchemaFS is a filesystem the schema files can be read from instead of the OS one. Names are always slash separated
+// and relative to its root.
+type SchemaFS interface {
+	ReadFile(name string) ([]byte, error)
+
+	// Glob returns the names of the files matching pattern, sorted. It should support the same patterns as
+	// filepath.Match, with ** as a whole path segment matching zero or more directories, which MatchGlob can be used for.
+	Glob(pattern string) ([]string, error)
+}
+
+// MatchGlob reports whether the slash separated name matches pattern, where a path segment of ** matches zero or more
+// directories.
+func MatchGlob(pattern string, name string) (bool, error) {
+	segments := strings.Split(pattern, "/")
+	for _, segment := range segments {
+		if strings.Contains(segment, "**") && segment != "**" {
+			return false, errors.Errorf("** must be a whole path segment")
+		}
+		if _, err := filepath.Match(segment, ""); err != nil {
+			return false, err
+		}
+	}
+	return matchSegments(segments, strings.Split(name, "/")), nil
+}
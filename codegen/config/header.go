@@ -0,0 +1,135 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Header is added to the top of every file gqlgen writes, after the "Code generated" line of generated files and
+// before the package clause.
+type Header struct {
+	// Lines are free-form comment lines, eg a license. They are commented with // unless they already are.
+	Lines []string `yaml:"lines,omitempty"`
+	// BuildConstraint is written as a // +build line, eg linux,!codeanalysis
+	BuildConstraint string `yaml:"build_constraint,omitempty"`
+	// Version stamps the gqlgen version that generated the file
+	Version bool `yaml:"version,omitempty"`
+	// Plugins stamps the names of the plugins that generated the file
+	Plugins bool `yaml:"plugins,omitempty"`
+	// Config stamps a hash of the config the file was generated with, see Config.Hash
+	Config bool `yaml:"config,omitempty"`
+
+	// PluginNames are the plugins taking part in generation, set by api.Generate for the Plugins stamp
+	PluginNames []string `yaml:"-"`
+	// ConfigHash is the hash of the effective config, set by api.Generate for the Config stamp
+	ConfigHash string `yaml:"-"`
+}
+
+func (h Header) Check() error {
+	for _, line := range h.Lines {
+		if strings.ContainsAny(line, "\r\n") {
+			return fmt.Errorf("lines can't contain newlines, give each line separately")
+		}
+	}
+	if err := checkBuildConstraint(h.BuildConstraint); err != nil {
+		return fmt.Errorf("build_constraint %q is not valid: %s", h.BuildConstraint, err.Error())
+	}
+	return nil
+}
+
+// checkBuildConstraint checks a constraint in the // +build syntax, space separated options of comma separated terms
+// that can each be negated with a !
+func checkBuildConstraint(constraint string) error {
+	for _, option := range strings.Fields(constraint) {
+		for _, term := range strings.Split(option, ",") {
+			tag := strings.TrimPrefix(term, "!")
+			if tag == "" {
+				return fmt.Errorf("empty term in %s", option)
+			}
+			for _, r := range tag {
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' {
+					return fmt.Errorf("unexpected %q in %s", r, term)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// String is the comment to put at the top of files, it is empty when nothing is configured
+func (h Header) String() string {
+	var lines []string
+	if h.Version || h.Plugins || h.Config {
+		stamp := "// generated by gqlgen"
+		if h.Version {
+			stamp += " " + version
+		}
+		if h.Plugins && len(h.PluginNames) > 0 {
+			stamp += " with " + strings.Join(h.PluginNames, ", ")
+		}
+		if h.Config && h.ConfigHash != "" {
+			stamp += " from config " + h.ConfigHash
+		}
+		lines = append(lines, stamp)
+	}
+	for _, line := range h.Lines {
+		switch {
+		case strings.HasPrefix(line, "//"):
+			lines = append(lines, line)
+		case line == "":
+			lines = append(lines, "//")
+		default:
+			lines = append(lines, "// "+line)
+		}
+	}
+
+	// a build constraint has to be followed by a blank line, and is kept apart from the rest so it isn't mistaken for
+	// a doc comment
+	var blocks []string
+	if len(lines) > 0 {
+		blocks = append(blocks, strings.Join(lines, "\n")+"\n")
+	}
+	if h.BuildConstraint != "" {
+		blocks = append(blocks, "// +build "+h.BuildConstraint+"\n")
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// Hash identifies the config, so files generated with different configs can be told apart. Filenames are made
+// relative to the working directory first, so the hash is the same wherever the code is generated.
+func (c *Config) Hash() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	rel := func(filename string) string {
+		if filename == "" || !filepath.IsAbs(filename) {
+			return filename
+		}
+		if r, err := filepath.Rel(wd, filename); err == nil {
+			return filepath.ToSlash(r)
+		}
+		return filename
+	}
+
+	normalized := *c
+	for _, pkg := range []*PackageConfig{&normalized.Exec, &normalized.Model, &normalized.Resolver} {
+		pkg.Filename = rel(pkg.Filename)
+		pkg.DirName = rel(pkg.DirName)
+	}
+	normalized.TemplateDir = rel(normalized.TemplateDir)
+
+	b, err := yaml.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:12], nil
+}
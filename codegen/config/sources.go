@@ -0,0 +1,310 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/lexer"
+	"github.com/vektah/gqlparser/parser"
+	"github.com/vektah/gqlparser/validator"
+)
+
+// dedupeAdditionalSources checks each of the AdditionalSources against everything loaded before it. Types and
+// directives that are declared again identically are removed from the additional source, and any that differ are
+// reported against both declarations. Sources that don't parse are passed through as is, so the parser can report them.
+func (c *Config) dedupeAdditionalSources(sources []*ast.Source) ([]*ast.Source, error) {
+	if len(c.AdditionalSources) == 0 {
+		return nil, nil
+	}
+
+	doc, perr := parser.ParseSchemas(sources...)
+	if perr != nil {
+		return c.AdditionalSources, nil
+	}
+
+	types := map[string]*ast.Definition{}
+	for _, def := range doc.Definitions {
+		types[def.Name] = def
+	}
+	directives := map[string]*ast.DirectiveDefinition{}
+	for _, dir := range doc.Directives {
+		directives[dir.Name] = dir
+	}
+
+	var deduped []*ast.Source
+	var errs []string
+	for _, source := range c.AdditionalSources {
+		doc, perr := parser.ParseSchema(source)
+		if perr != nil {
+			deduped = append(deduped, source)
+			continue
+		}
+
+		var duplicates []*ast.Position
+		for _, def := range doc.Definitions {
+			existing, ok := types[def.Name]
+			switch {
+			case !ok:
+				types[def.Name] = def
+			case ast.Dump(existing) == ast.Dump(def):
+				duplicates = append(duplicates, def.Position)
+			default:
+				errs = append(errs, conflictMessage(def.Position, "type "+def.Name, existing.Position))
+			}
+		}
+		for _, dir := range doc.Directives {
+			existing, ok := directives[dir.Name]
+			switch {
+			case !ok:
+				directives[dir.Name] = dir
+			case ast.Dump(existing) == ast.Dump(dir):
+				duplicates = append(duplicates, dir.Position)
+			default:
+				errs = append(errs, conflictMessage(dir.Position, "directive @"+dir.Name, existing.Position))
+			}
+		}
+
+		if len(duplicates) > 0 {
+			input, err := withoutDefinitions(source, duplicates)
+			if err != nil {
+				return nil, err
+			}
+			source = &ast.Source{Name: source.Name, Input: input, BuiltIn: source.BuiltIn}
+		}
+		deduped = append(deduped, source)
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return deduped, nil
+}
+
+func conflictMessage(pos *ast.Position, name string, existing *ast.Position) string {
+	return fmt.Sprintf(
+		"%s:%d: %s was added to the schema by a plugin, but is already declared differently at %s:%d",
+		pos.Src.Name,
+		pos.Line,
+		name,
+		existing.Src.Name,
+		existing.Line,
+	)
+}
+
+var definitionKeywords = map[string]bool{
+	"schema": true, "scalar": true, "type": true, "interface": true, "union": true, "enum": true, "input": true,
+	"directive": true, "extend": true,
+}
+
+// withoutDefinitions removes the top level definitions containing each of the positions from the source, along with
+// their descriptions.
+func withoutDefinitions(source *ast.Source, positions []*ast.Position) (string, error) {
+	var starts []int
+	var prev *lexer.Token
+	var description *lexer.Token
+	depth := 0
+	afterKeyword := false
+
+	l := lexer.New(source)
+	for {
+		tok, err := l.ReadToken()
+		if err != nil {
+			return "", err
+		}
+		if tok.Kind == lexer.EOF {
+			break
+		}
+
+		switch tok.Kind {
+		case lexer.BraceL, lexer.ParenL:
+			depth++
+		case lexer.BraceR, lexer.ParenR:
+			depth--
+		}
+		if depth > 0 || tok.Kind == lexer.BraceR || tok.Kind == lexer.ParenR {
+			prev, description, afterKeyword = &tok, nil, false
+			continue
+		}
+
+		isStart := tok.Kind == lexer.Name && definitionKeywords[tok.Value] && !afterKeyword
+		if isStart && prev != nil {
+			switch prev.Kind {
+			case lexer.At, lexer.Equals, lexer.Pipe, lexer.Amp:
+				isStart = false
+			case lexer.Name:
+				isStart = prev.Value != "implements"
+			}
+		}
+
+		if isStart {
+			if description != nil {
+				starts = append(starts, description.Pos.Start)
+			} else {
+				starts = append(starts, tok.Pos.Start)
+			}
+		}
+
+		afterKeyword = isStart
+		description = nil
+		if tok.Kind == lexer.String || tok.Kind == lexer.BlockString {
+			description = &tok
+		}
+		prev = &tok
+	}
+
+	if len(starts) == 0 {
+		return source.Input, nil
+	}
+
+	input := []rune(source.Input)
+	drop := make([]bool, len(starts))
+	for _, pos := range positions {
+		for i, start := range starts {
+			if start <= pos.Start && (i == len(starts)-1 || pos.Start < starts[i+1]) {
+				drop[i] = true
+			}
+		}
+	}
+
+	var kept strings.Builder
+	kept.WriteString(string(input[:starts[0]]))
+	for i, start := range starts {
+		end := len(input)
+		if i < len(starts)-1 {
+			end = starts[i+1]
+		}
+		if !drop[i] {
+			kept.WriteString(string(input[start:end]))
+		}
+	}
+	return kept.String(), nil
+}
+
+// WithoutDirective removes the definition of the named directive from the source, along with everywhere it is used. It
+// keeps directives that are only read by gqlgen out of the schema that is served.
+func WithoutDirective(source *ast.Source, name string) (string, error) {
+	doc, perr := parser.ParseSchema(source)
+	if perr != nil {
+		return "", perr
+	}
+
+	input := source.Input
+	var positions []*ast.Position
+	for _, dir := range doc.Directives {
+		if dir.Name == name {
+			positions = append(positions, dir.Position)
+		}
+	}
+	if len(positions) > 0 {
+		var err error
+		input, err = withoutDefinitions(source, positions)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var tokens []lexer.Token
+	l := lexer.New(&ast.Source{Name: source.Name, Input: input})
+	for {
+		tok, err := l.ReadToken()
+		if err != nil {
+			return "", err
+		}
+		if tok.Kind == lexer.EOF {
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+
+	runes := []rune(input)
+	var kept strings.Builder
+	last := 0
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Kind != lexer.At || i+1 == len(tokens) || tokens[i+1].Kind != lexer.Name || tokens[i+1].Value != name {
+			continue
+		}
+
+		start := tokens[i].Pos.Start
+		for start > last && (runes[start-1] == ' ' || runes[start-1] == '\t') {
+			start--
+		}
+		i++
+		end := tokens[i].Pos.End
+		if i+1 < len(tokens) && tokens[i+1].Kind == lexer.ParenL {
+			for depth := 0; i+1 < len(tokens); {
+				i++
+				if tokens[i].Kind == lexer.ParenL {
+					depth++
+				} else if tokens[i].Kind == lexer.ParenR {
+					depth--
+				}
+				if depth == 0 {
+					break
+				}
+			}
+			end = tokens[i].Pos.End
+		}
+
+		kept.WriteString(string(runes[last:start]))
+		last = end
+	}
+	kept.WriteString(string(runes[last:]))
+
+	return kept.String(), nil
+}
+
+// checkUnionMembers makes sure every union member is a declared object type before the schema is validated, so all of
+// the bad members can be reported at once along with where they were declared. Sources that don't parse are left for
+// the parser to report.
+func checkUnionMembers(sources []*ast.Source) error {
+	doc, perr := parser.ParseSchemas(append([]*ast.Source{validator.Prelude}, sources...)...)
+	if perr != nil {
+		return nil
+	}
+
+	types := map[string]*ast.Definition{}
+	for _, def := range doc.Definitions {
+		types[def.Name] = def
+	}
+
+	var errs []string
+	for _, union := range append(append(ast.DefinitionList{}, doc.Definitions...), doc.Extensions...) {
+		if union.Kind != ast.Union {
+			continue
+		}
+		for _, name := range union.Types {
+			member := types[name]
+			switch {
+			case member == nil:
+				errs = append(errs, fmt.Sprintf("%s:%d: union %s member %s is not defined",
+					union.Position.Src.Name, union.Position.Line, union.Name, name))
+			case member.Kind != ast.Object:
+				errs = append(errs, fmt.Sprintf("%s:%d: union %s member %s must be an object type, but is %s declared at %s:%d",
+					union.Position.Src.Name, union.Position.Line, union.Name, name, kindName(member.Kind), member.Position.Src.Name, member.Position.Line))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func kindName(kind ast.DefinitionKind) string {
+	switch kind {
+	case ast.Scalar:
+		return "a scalar"
+	case ast.Interface:
+		return "an interface"
+	case ast.Union:
+		return "a union"
+	case ast.Enum:
+		return "an enum"
+	case ast.InputObject:
+		return "an input"
+	default:
+		return "an object"
+	}
+}
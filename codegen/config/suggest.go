@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/99designs/gqlgen/internal/code"
+)
+
+// maxListedTypes is how many of a package's exported types are listed when a type can't be found in it
+const maxListedTypes = 20
+
+// typeNotFoundError lists the exported types of the package the type was expected in, with the closest one first
+// when it looks like a typo.
+func typeNotFoundError(fullName string, pkg *types.Package, typeName string) error {
+	names := exportedTypeNames(pkg)
+	if len(names) == 0 {
+		return fmt.Errorf("unable to find type %s, %s has no exported types", fullName, pkg.Path())
+	}
+
+	msg := "unable to find type " + fullName + ","
+	if match := closestMatch(typeName, names); match != "" {
+		msg += " did you mean " + match + "?"
+	}
+
+	listed := names
+	if len(listed) > maxListedTypes {
+		listed = listed[:maxListedTypes]
+	}
+	msg += fmt.Sprintf(" %s has types %s", pkg.Path(), strings.Join(listed, ", "))
+	if len(names) > len(listed) {
+		msg += fmt.Sprintf(" and %d more", len(names)-len(listed))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// packageLoadError wraps the error packages.Load gave for a package, noting whether go.mod provides it as that is
+// usually why it didn't load.
+func packageLoadError(pkgPath string, err error) error {
+	msg := fmt.Sprintf("unable to load package %s: %s", pkgPath, err.Error())
+
+	wd, _ := os.Getwd()
+	if gomod, provided := code.GoModProvides(wd, pkgPath); gomod == "" {
+		msg += "; there is no go.mod"
+	} else if provided {
+		msg += "; its module is in " + gomod
+	} else {
+		msg += "; its module is not in " + gomod
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+func exportedTypeNames(pkg *types.Package) []string {
+	var names []string
+	for _, name := range pkg.Scope().Names() {
+		if obj, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok && obj.Exported() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// closestMatch returns the candidate that is the fewest edits from name, ignoring case, if it is close enough to be
+// a typo
+func closestMatch(name string, candidates []string) string {
+	maxDistance := 1
+	if len(name) >= 8 {
+		maxDistance = 2
+	}
+
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		if candidate == name {
+			continue
+		}
+		if distance := editDistance(strings.ToLower(name), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the number of insertions, deletions, substitutions and transpositions of adjacent characters it
+// takes to turn a into b
+func editDistance(a string, b string) int {
+	ar, br := []rune(a), []rune(b)
+	d := make([][]int, len(ar)+1)
+	for i := range d {
+		d[i] = make([]int, len(br)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ar)][len(br)]
+}
+
+func minInt(first int, rest ...int) int {
+	for _, v := range rest {
+		if v < first {
+			first = v
+		}
+	}
+	return first
+}
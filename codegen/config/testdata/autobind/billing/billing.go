@@ -0,0 +1,13 @@
+package billing
+
+type Invoice struct {
+	ID string
+}
+
+type Plan struct {
+	Name string
+}
+
+type Order struct {
+	ID string
+}
@@ -0,0 +1,14 @@
+package users
+
+type User struct {
+	Name string
+}
+
+type Invoice struct {
+	ID string
+}
+
+// Plan and Cart are not types, so they must not be autobound
+var Plan = "free"
+
+func Cart() {}
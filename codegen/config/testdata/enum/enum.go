@@ -0,0 +1,13 @@
+package enum
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+	StatusDisabled = StatusInactive
+)
+
+type Other int
+
+const OtherValue Other = 0
@@ -0,0 +1,29 @@
+// +build go1.12
+
+package config
+
+import (
+	"runtime/debug"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// version is the version of the gqlgen module from the build info, which is there when gqlgen is run as a
+// dependency. Builds of gqlgen itself fall back to graphql.Version.
+var version = func() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		modules := append([]*debug.Module{&info.Main}, info.Deps...)
+		for _, mod := range modules {
+			if mod.Path != "github.com/99designs/gqlgen" {
+				continue
+			}
+			if mod.Replace != nil {
+				mod = mod.Replace
+			}
+			if mod.Version != "" && mod.Version != "(devel)" {
+				return mod.Version
+			}
+		}
+	}
+	return graphql.Version
+}()
@@ -0,0 +1,8 @@
+// +build !go1.12
+
+package config
+
+import "github.com/99designs/gqlgen/graphql"
+
+// version is graphql.Version, there is no build info to read the module version from before go 1.12
+var version = graphql.Version
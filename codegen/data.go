@@ -2,7 +2,11 @@ package codegen
 
 import (
 	"fmt"
+	"runtime"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/99designs/gqlgen/codegen/config"
 	"github.com/pkg/errors"
@@ -15,10 +19,12 @@ type Data struct {
 	Config          *config.Config
 	Schema          *ast.Schema
 	SchemaStr       map[string]string
+	Sources         []*ast.Source
 	Directives      map[string]*Directive
 	Objects         Objects
 	Inputs          Objects
 	Interfaces      map[string]*Interface
+	Enums           []*Enum
 	ReferencedTypes map[string]*config.TypeReference
 	ComplexityRoots map[string]*Object
 
@@ -31,37 +37,82 @@ type builder struct {
 	Config     *config.Config
 	Schema     *ast.Schema
 	SchemaStr  map[string]string
+	Sources    []*ast.Source
 	Binder     *config.Binder
 	Directives map[string]*Directive
+	TypeFilter func(def *ast.Definition) bool
 }
 
 func BuildData(cfg *config.Config) (*Data, error) {
+	return BuildPartialData(cfg, nil)
+}
+
+// BuildPartialData is like BuildData, but only builds the objects and inputs that filter returns true for. The whole
+// schema is still loaded and validated, and the root and introspection types are always built. A nil filter builds
+// everything.
+func BuildPartialData(cfg *config.Config, filter func(def *ast.Definition) bool) (*Data, error) {
 	b := builder{
-		Config: cfg,
+		Config:     cfg,
+		TypeFilter: filter,
 	}
 
 	var err error
-	b.Schema, b.SchemaStr, err = cfg.LoadSchema()
+	done := b.trace("load schema")
+	b.Schema, b.Sources, err = cfg.LoadSchemaSources()
 	if err != nil {
 		return nil, err
 	}
 
-	err = cfg.Check()
-	if err != nil {
-		return nil, err
+	b.SchemaStr = map[string]string{}
+	_, hasGoField := b.Schema.Directives["goField"]
+	for _, source := range b.Sources {
+		input := source.Input
+		if hasGoField {
+			// @goField is only read while generating, so it is left out of the schema that is served
+			input, err = config.WithoutDirective(source, "goField")
+			if err != nil {
+				return nil, err
+			}
+		}
+		b.SchemaStr[source.Name] = input
+	}
+	done(len(b.Schema.Types))
+
+	var errs BuildErrors
+	if err := cfg.Check(); err != nil {
+		errs = errs.add(err)
 	}
 
 	cfg.InjectBuiltins(b.Schema)
 
+	done = b.trace("autobind")
+	if err := cfg.Autobind(b.Schema); err != nil {
+		errs = errs.add(err)
+	}
+	done(len(cfg.Models))
+
+	if err := checkInputCycles(b.Schema); err != nil {
+		errs = errs.add(err)
+	}
+
+	// binding needs a valid config, and everything after it needs a binder
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	done = b.trace("bind")
 	b.Binder, err = b.Config.NewBinder(b.Schema)
 	if err != nil {
 		return nil, err
 	}
+	done(0)
 
+	done = b.trace("directives")
 	b.Directives, err = b.buildDirectives()
 	if err != nil {
 		return nil, err
 	}
+	done(len(b.Directives))
 
 	dataDirectives := make(map[string]*Directive)
 	for name, d := range b.Directives {
@@ -75,53 +126,64 @@ func BuildData(cfg *config.Config) (*Data, error) {
 		Directives: dataDirectives,
 		Schema:     b.Schema,
 		SchemaStr:  b.SchemaStr,
+		Sources:    b.Sources,
 		Interfaces: map[string]*Interface{},
 	}
 
-	for _, schemaType := range b.Schema.Types {
-		switch schemaType.Kind {
-		case ast.Object:
-			obj, err := b.buildObject(schemaType)
-			if err != nil {
-				return nil, errors.Wrap(err, "unable to build object definition")
-			}
-
-			s.Objects = append(s.Objects, obj)
-		case ast.InputObject:
-			input, err := b.buildObject(schemaType)
-			if err != nil {
-				return nil, errors.Wrap(err, "unable to build input definition")
-			}
-
-			s.Inputs = append(s.Inputs, input)
-
-		case ast.Union, ast.Interface:
-			s.Interfaces[schemaType.Name] = b.buildInterface(schemaType)
+	done = b.trace("types")
+	names := b.buildableTypeNames()
+	for _, built := range b.buildTypeDefinitions(names) {
+		switch {
+		case built.err != nil:
+			errs = errs.add(built.err)
+		case built.object != nil:
+			s.Objects = append(s.Objects, built.object)
+		case built.input != nil:
+			s.Inputs = append(s.Inputs, built.input)
+		case built.intf != nil:
+			s.Interfaces[built.intf.Name] = built.intf
+		case built.enum != nil:
+			s.Enums = append(s.Enums, built.enum)
 		}
 	}
 
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	done(len(names))
+
+	s.indexObjects()
+
 	if s.Schema.Query != nil {
-		s.QueryRoot = s.Objects.ByName(s.Schema.Query.Name)
+		s.QueryRoot = s.ObjectByName(s.Schema.Query.Name)
 	} else {
 		return nil, fmt.Errorf("query entry point missing")
 	}
 
 	if s.Schema.Mutation != nil {
-		s.MutationRoot = s.Objects.ByName(s.Schema.Mutation.Name)
+		s.MutationRoot = s.ObjectByName(s.Schema.Mutation.Name)
 	}
 
 	if s.Schema.Subscription != nil {
-		s.SubscriptionRoot = s.Objects.ByName(s.Schema.Subscription.Name)
+		s.SubscriptionRoot = s.ObjectByName(s.Schema.Subscription.Name)
 	}
 
-	if err := b.injectIntrospectionRoots(&s); err != nil {
+	done = b.trace("introspection")
+	if cfg.OmitIntrospection {
+		if err := checkNoIntrospectionReferences(&s); err != nil {
+			return nil, err
+		}
+	} else if err := b.injectIntrospectionRoots(&s); err != nil {
 		return nil, err
 	}
+	done(0)
 
+	done = b.trace("references")
 	s.ReferencedTypes, err = b.buildTypes()
 	if err != nil {
 		return nil, err
 	}
+	done(len(s.ReferencedTypes))
 
 	sort.Slice(s.Objects, func(i, j int) bool {
 		return s.Objects[i].Definition.Name < s.Objects[j].Definition.Name
@@ -131,11 +193,221 @@ func BuildData(cfg *config.Config) (*Data, error) {
 		return s.Inputs[i].Definition.Name < s.Inputs[j].Definition.Name
 	})
 
+	// introspection added objects and the sort moved them all
+	s.indexObjects()
+
+	s.ComplexityRoots, err = buildComplexityRoots(cfg.Complexity, b.Schema, s.Objects)
+	if err != nil {
+		return nil, err
+	}
+
 	return &s, nil
 }
 
+// buildComplexityRoots picks out the objects that get an entry in the generated ComplexityRoot.
+func buildComplexityRoots(cfg config.Complexity, schema *ast.Schema, objects Objects) (map[string]*Object, error) {
+	var errs BuildErrors
+	for _, name := range append(append(config.StringList{}, cfg.Include...), cfg.Exclude...) {
+		if def := schema.Types[name]; def == nil || def.Kind != ast.Object || strings.HasPrefix(name, "__") {
+			errs = append(errs, fmt.Errorf("complexity: %s is not an object in the schema", name))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	roots := map[string]*Object{}
+	for _, obj := range objects {
+		switch {
+		case obj.IsReserved(), cfg.Exclude.Has(obj.Name):
+			continue
+		case len(cfg.Include) > 0:
+			if !cfg.Include.Has(obj.Name) {
+				continue
+			}
+		case strings.HasPrefix(obj.Name, "_"):
+			continue
+		}
+		roots[obj.Name] = obj
+	}
+	return roots, nil
+}
+
+func (d *Data) indexObjects() {
+	d.Objects.index()
+	d.Inputs.index()
+}
+
+// ObjectByName finds an object by its exact schema name, ByName ignores case.
+func (d *Data) ObjectByName(name string) *Object {
+	// nothing folds to the name when ByName finds nothing, so only a different case needs the scan
+	if o := d.Objects.ByName(name); o == nil || o.Definition.Name == name {
+		return o
+	}
+	return d.Objects.ByNameExact(name)
+}
+
+// InputByName finds an input by its exact schema name, ByName ignores case.
+func (d *Data) InputByName(name string) *Object {
+	if o := d.Inputs.ByName(name); o == nil || o.Definition.Name == name {
+		return o
+	}
+	return d.Inputs.ByNameExact(name)
+}
+
+func noTrace(int) {}
+
+// trace starts timing a phase of BuildData, calling the returned func reports it to Config.OnBuildPhase. Nothing is
+// timed when there is no callback.
+func (b *builder) trace(name string) func(count int) {
+	if b.Config.OnBuildPhase == nil {
+		return noTrace
+	}
+
+	start := time.Now()
+	return func(count int) {
+		b.Config.OnBuildPhase(config.BuildPhase{Name: name, Duration: time.Since(start), Count: count})
+	}
+}
+
+// buildWorkers is the number of schema types built concurrently, defaulting to GOMAXPROCS when zero
+var buildWorkers = 0
+
+type builtDefinition struct {
+	object     *Object
+	input      *Object
+	intf       *Interface
+	enum       *Enum
+	err        error
+	references []*config.TypeReference
+}
+
+// buildTypeDefinitions builds the named schema types across a pool of workers. Each type is bound using its own copy of
+// the Binder and the references collected are merged back in the order of names, so the result is the same as building
+// them one at a time.
+func (b *builder) buildTypeDefinitions(names []string) []builtDefinition {
+	workers := buildWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]builtDefinition, len(names))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				results[i] = b.buildTypeDefinition(b.Schema.Types[names[i]])
+			}
+		}()
+	}
+	for i := range names {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	for _, result := range results {
+		b.Binder.References = append(b.Binder.References, result.references...)
+	}
+
+	return results
+}
+
+func (b *builder) buildTypeDefinition(schemaType *ast.Definition) builtDefinition {
+	binder := *b.Binder
+	binder.References = nil
+	tb := *b
+	tb.Binder = &binder
+
+	var built builtDefinition
+	var err error
+	switch schemaType.Kind {
+	case ast.Object:
+		built.object, err = tb.buildObject(schemaType)
+		if err != nil {
+			built.err = BuildErrors{}.addWrapped(err, "unable to build object definition")
+		}
+	case ast.InputObject:
+		built.input, err = tb.buildObject(schemaType)
+		if err != nil {
+			built.err = BuildErrors{}.addWrapped(err, "unable to build input definition")
+		}
+	case ast.Union, ast.Interface:
+		built.intf, err = tb.buildInterface(schemaType)
+		if err != nil {
+			built.err = BuildErrors{}.addWrapped(err, "unable to build interface definition")
+		}
+	case ast.Enum:
+		built.enum, err = tb.buildEnum(schemaType)
+		if err != nil {
+			built.err = BuildErrors{}.addWrapped(err, "unable to build enum definition")
+		}
+	}
+	built.references = binder.References
+
+	return built
+}
+
+// buildableTypeNames returns the sorted names of the schema types to build, leaving out the introspection types when
+// they have been omitted and any objects or inputs rejected by the TypeFilter.
+func (b *builder) buildableTypeNames() []string {
+	names := sortedTypeNames(b.Schema)
+	if !b.Config.OmitIntrospection && b.TypeFilter == nil {
+		return names
+	}
+
+	buildable := names[:0]
+	for _, name := range names {
+		if strings.HasPrefix(name, "__") {
+			if !b.Config.OmitIntrospection {
+				buildable = append(buildable, name)
+			}
+			continue
+		}
+
+		def := b.Schema.Types[name]
+		isRoot := def == b.Schema.Query || def == b.Schema.Mutation || def == b.Schema.Subscription
+		isFiltered := def.Kind == ast.Object || def.Kind == ast.InputObject
+		if b.TypeFilter == nil || isRoot || !isFiltered || b.TypeFilter(def) {
+			buildable = append(buildable, name)
+		}
+	}
+	return buildable
+}
+
+func sortedTypeNames(schema *ast.Schema) []string {
+	names := make([]string, 0, len(schema.Types))
+	for name := range schema.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkNoIntrospectionReferences makes sure nothing still needs the introspection types that omit_introspection leaves
+// out of the generated code.
+func checkNoIntrospectionReferences(s *Data) error {
+	var errs BuildErrors
+	for _, obj := range append(append(Objects{}, s.Objects...), s.Inputs...) {
+		for _, field := range obj.Fields {
+			if strings.HasPrefix(field.Type.Name(), "__") {
+				errs = append(errs, fmt.Errorf("%s: references %s, which is not generated when omit_introspection is set",
+					withPosition(field.Position, obj.Name+"."+field.Name), field.Type.Name()))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
 func (b *builder) injectIntrospectionRoots(s *Data) error {
-	obj := s.Objects.ByName(b.Schema.Query.Name)
+	obj := s.ObjectByName(b.Schema.Query.Name)
 	if obj == nil {
 		return fmt.Errorf("root query type must be defined")
 	}
@@ -151,7 +423,7 @@ func (b *builder) injectIntrospectionRoots(s *Data) error {
 		},
 	})
 	if err != nil {
-		return err
+		return errors.Wrap(err, "injecting __type field")
 	}
 
 	__schema, err := b.buildField(obj, &ast.FieldDefinition{
@@ -159,7 +431,7 @@ func (b *builder) injectIntrospectionRoots(s *Data) error {
 		Type: ast.NamedType("__Schema", nil),
 	})
 	if err != nil {
-		return err
+		return errors.Wrap(err, "injecting __schema field")
 	}
 
 	obj.Fields = append(obj.Fields, __type, __schema)
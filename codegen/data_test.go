@@ -0,0 +1,491 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/codegen/config"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// testConfig is the config for building the schema files, with models bound as given
+func testConfig(models config.TypeMap, schemaFilenames ...string) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.SchemaFilename = schemaFilenames
+	cfg.Exec = config.PackageConfig{Filename: "generated.go"}
+	cfg.Model = config.PackageConfig{Filename: "models.go"}
+	cfg.Models = models
+	return cfg
+}
+
+func TestBuildDataReportsAllErrors(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"User": {Model: config.StringList{"github.com/99designs/gqlgen/codegen/testdata/fieldmatching.MissingUser"}},
+		"Post": {Model: config.StringList{"github.com/99designs/gqlgen/codegen/testdata/fieldmatching.MissingPost"}},
+	}, "testdata/builderrors/schema.graphql")
+
+	_, err := BuildData(cfg)
+	require.Error(t, err)
+
+	errs, ok := err.(BuildErrors)
+	require.True(t, ok, "expected BuildErrors, got %T", err)
+	require.Len(t, errs, 4)
+	require.EqualError(t, errs[0], "unable to build object definition: testdata/builderrors/schema.graphql:10: Post: unable to find type github.com/99designs/gqlgen/codegen/testdata/fieldmatching.MissingPost, github.com/99designs/gqlgen/codegen/testdata/fieldmatching has types User")
+	require.EqualError(t, errs[1], "unable to build object definition: testdata/builderrors/schema.graphql:2: Query.user: unable to find type github.com/99designs/gqlgen/codegen/testdata/fieldmatching.MissingUser, github.com/99designs/gqlgen/codegen/testdata/fieldmatching has types User")
+	require.EqualError(t, errs[2], "unable to build object definition: testdata/builderrors/schema.graphql:3: Query.post: unable to find type github.com/99designs/gqlgen/codegen/testdata/fieldmatching.MissingPost, github.com/99designs/gqlgen/codegen/testdata/fieldmatching has types User")
+	require.EqualError(t, errs[3], "unable to build object definition: testdata/builderrors/schema.graphql:6: User: unable to find type github.com/99designs/gqlgen/codegen/testdata/fieldmatching.MissingUser, github.com/99designs/gqlgen/codegen/testdata/fieldmatching has types User")
+}
+
+func TestBuildDataReportsAllBindingErrors(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"User": {Model: config.StringList{"github.com/99designs/gqlgen/codegen/testdata/builderrors.User"}},
+	}, "testdata/builderrors/bindings.graphql")
+	cfg.FieldMatching = []config.FieldMatching{config.FieldMatchingSnake}
+
+	_, err := BuildData(cfg)
+	require.Error(t, err)
+
+	// nickname doesn't bind either, but it still falls back to a resolver
+	errs, ok := err.(BuildErrors)
+	require.True(t, ok, "expected BuildErrors, got %T", err)
+	require.Len(t, errs, 2)
+	require.Contains(t, errs[0].Error(), "testdata/builderrors/bindings.graphql:8: User.userId: ")
+	require.Contains(t, errs[0].Error(), "matches more than one go field with snake field matching: UserID, User_ID")
+	require.Contains(t, errs[1].Error(), "testdata/builderrors/bindings.graphql:9: User.ownerId: ")
+	require.Contains(t, errs[1].Error(), "matches more than one go field with snake field matching: OwnerID, Owner_ID")
+}
+
+func TestBuildDataReportsConfigAndSchemaErrors(t *testing.T) {
+	cfg := testConfig(nil, "testdata/inputcycles/illegal.graphql")
+	cfg.Exec.Layout = "follow-schema"
+
+	_, err := BuildData(cfg)
+	require.Error(t, err)
+
+	errs, ok := err.(BuildErrors)
+	require.True(t, ok, "expected BuildErrors, got %T", err)
+	require.Len(t, errs, 3)
+	require.EqualError(t, errs[0], "config.exec: layout should be one of single-file or split")
+	require.Contains(t, errs[1].Error(), "Filter.range: input can never be constructed")
+	require.Contains(t, errs[2].Error(), "Self.self: input can never be constructed")
+}
+
+func TestBuildDataEnumValueDirectives(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"Role": {Model: config.StringList{"github.com/99designs/gqlgen/graphql.String"}},
+	}, "testdata/enumdirectives/schema.graphql")
+
+	data, err := BuildData(cfg)
+	require.NoError(t, err)
+
+	var role *Enum
+	for _, e := range data.Enums {
+		if e.Name == "Role" {
+			role = e
+		}
+	}
+	require.NotNil(t, role)
+	require.Len(t, role.Values, 4)
+
+	require.Equal(t, "ADMIN", role.Values[0].Name)
+	require.Len(t, role.Values[0].Directives, 1)
+	require.Equal(t, "sensitive", role.Values[0].Directives[0].Name)
+	require.Equal(t, "elevated", role.Values[0].Directives[0].Args[0].Value)
+
+	require.Equal(t, "USER", role.Values[1].Name)
+	require.Len(t, role.Values[1].Directives, 1)
+	require.Equal(t, "pii", role.Values[1].Directives[0].Args[0].Value)
+
+	require.Empty(t, role.Values[2].Directives)
+	require.False(t, role.Values[2].IsDeprecated)
+
+	require.True(t, role.Values[3].IsDeprecated)
+	require.Equal(t, "use ADMIN", role.Values[3].DeprecationReason)
+
+	admin := data.QueryRoot.Fields[1]
+	require.Equal(t, "admin", admin.Name)
+	require.True(t, admin.IsDeprecated)
+	require.Equal(t, "No longer supported", admin.DeprecationReason)
+}
+
+func TestBuildDataInputCycles(t *testing.T) {
+	build := func(schema string) (*Data, error) {
+		cfg := testConfig(config.TypeMap{}, schema)
+		for _, name := range []string{"Filter", "Range", "Self"} {
+			cfg.Models[name] = config.TypeMapEntry{Model: config.StringList{"map[string]interface{}"}}
+		}
+		return BuildData(cfg)
+	}
+
+	t.Run("cycles broken by nullable or list fields are allowed", func(t *testing.T) {
+		_, err := build("testdata/inputcycles/legal.graphql")
+		require.NoError(t, err)
+	})
+
+	t.Run("cycles of non-null fields are reported with their path", func(t *testing.T) {
+		_, err := build("testdata/inputcycles/illegal.graphql")
+		require.Error(t, err)
+
+		errs, ok := err.(BuildErrors)
+		require.True(t, ok, "expected BuildErrors, got %T", err)
+		require.Len(t, errs, 2)
+		require.EqualError(t, errs[0], "testdata/inputcycles/illegal.graphql:7: Filter.range: input can never be constructed, non-null fields form a cycle: Filter.range -> Range.filter -> Filter")
+		require.EqualError(t, errs[1], "testdata/inputcycles/illegal.graphql:17: Self.self: input can never be constructed, non-null fields form a cycle: Self.self -> Self")
+	})
+}
+
+func TestBuildDataForceResolver(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"User": {Model: config.StringList{"github.com/99designs/gqlgen/codegen/testdata/forceresolver.User"}},
+	}, "testdata/forceresolver/schema.graphql")
+
+	data, err := BuildData(cfg)
+	require.NoError(t, err)
+
+	user := data.ObjectByName("User")
+	require.False(t, user.Fields[0].IsResolver)
+	require.True(t, user.Fields[1].IsResolver)
+	require.False(t, user.Fields[2].IsResolver)
+
+	require.NotContains(t, data.Directives, "goField")
+	schema := data.SchemaStr["testdata/forceresolver/schema.graphql"]
+	require.NotContains(t, schema, "goField")
+	require.NotContains(t, schema, "only read by gqlgen")
+	require.Contains(t, schema, "    orderCount: Int!\n")
+
+	_, gerr := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schema})
+	require.Nil(t, gerr)
+}
+
+func TestBuildDataNullability(t *testing.T) {
+	build := func(strict bool, fields map[string]config.TypeMapField) (*Data, error) {
+		cfg := testConfig(config.TypeMap{
+			"User":      {Model: config.StringList{"github.com/99designs/gqlgen/codegen/testdata/nullability.User"}, Fields: fields},
+			"UserInput": {Model: config.StringList{"github.com/99designs/gqlgen/codegen/testdata/nullability.UserInput"}},
+		}, "testdata/nullability/schema.graphql")
+		cfg.NullabilityStrict = strict
+		return BuildData(cfg)
+	}
+
+	t.Run("mismatches are only logged by default", func(t *testing.T) {
+		data, err := build(false, nil)
+		require.NoError(t, err)
+		require.Len(t, data.ObjectByName("User").Fields, 3)
+	})
+
+	t.Run("strict mismatches are reported with the shape that would match", func(t *testing.T) {
+		_, err := build(true, nil)
+		require.Error(t, err)
+
+		errs, ok := err.(BuildErrors)
+		require.True(t, ok, "expected BuildErrors, got %T", err)
+		require.Len(t, errs, 4)
+		require.EqualError(t, errs[0], "unable to build object definition: testdata/nullability/schema.graphql:7: User.email is String! but bound to *string; use String or change the Go field")
+		require.EqualError(t, errs[1], "unable to build object definition: testdata/nullability/schema.graphql:8: User.tags is [String!] but bound to []*string; use [String] or change the Go field")
+		require.EqualError(t, errs[2], "unable to build input definition: testdata/nullability/schema.graphql:13: UserInput.email is String but bound to string; use String! or change the Go field")
+		require.EqualError(t, errs[3], "unable to build input definition: testdata/nullability/schema.graphql:14: UserInput.tags is [String] but bound to []string; use [String!] or change the Go field")
+	})
+
+	t.Run("fields can opt out", func(t *testing.T) {
+		_, err := build(true, map[string]config.TypeMapField{
+			"email": {IgnoreNullability: true},
+			"tags":  {IgnoreNullability: true},
+		})
+		require.Error(t, err)
+
+		errs, ok := err.(BuildErrors)
+		require.True(t, ok, "expected BuildErrors, got %T", err)
+		require.Len(t, errs, 2)
+		require.Contains(t, errs[0].Error(), "UserInput.email")
+		require.Contains(t, errs[1].Error(), "UserInput.tags")
+	})
+}
+
+func TestBuildDataOmitIntrospection(t *testing.T) {
+	build := func(schema string, omit bool) (*Data, error) {
+		cfg := testConfig(config.TypeMap{
+			"User": {Model: config.StringList{"map[string]interface{}"}},
+		}, schema)
+		cfg.OmitIntrospection = omit
+		return BuildData(cfg)
+	}
+
+	t.Run("introspection is generated by default", func(t *testing.T) {
+		data, err := build("testdata/omitintrospection/schema.graphql", false)
+		require.NoError(t, err)
+
+		require.NotNil(t, data.ObjectByName("__Schema"))
+		require.Contains(t, fieldNames(data.QueryRoot), "__schema")
+		require.Contains(t, fieldNames(data.QueryRoot), "__type")
+	})
+
+	t.Run("omitted introspection leaves out the types and roots", func(t *testing.T) {
+		data, err := build("testdata/omitintrospection/schema.graphql", true)
+		require.NoError(t, err)
+
+		for _, obj := range data.Objects {
+			require.False(t, strings.HasPrefix(obj.Name, "__"), "%s should not be built", obj.Name)
+		}
+		for _, ref := range data.ReferencedTypes {
+			require.False(t, strings.HasPrefix(ref.Definition.Name, "__"), "%s should not be referenced", ref.Definition.Name)
+		}
+		require.Equal(t, []string{"user"}, fieldNames(data.QueryRoot))
+	})
+
+	t.Run("fields needing introspection types fail", func(t *testing.T) {
+		_, err := build("testdata/omitintrospection/references.graphql", true)
+		require.EqualError(t, err, "testdata/omitintrospection/references.graphql:3: Query.schema: references __Schema, which is not generated when omit_introspection is set")
+	})
+}
+
+func TestBuildDataComplexityRoots(t *testing.T) {
+	build := func(complexity config.Complexity) (*Data, error) {
+		cfg := testConfig(config.TypeMap{
+			"User":     {Model: config.StringList{"map[string]interface{}"}},
+			"AuditLog": {Model: config.StringList{"map[string]interface{}"}},
+			"_Service": {Model: config.StringList{"map[string]interface{}"}},
+		}, "testdata/complexity/schema.graphql")
+		cfg.Complexity = complexity
+		return BuildData(cfg)
+	}
+	rootNames := func(data *Data) []string {
+		var names []string
+		for name := range data.ComplexityRoots {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	t.Run("defaults leave out underscored types", func(t *testing.T) {
+		data, err := build(config.Complexity{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"AuditLog", "Query", "User"}, rootNames(data))
+	})
+
+	t.Run("include and exclude", func(t *testing.T) {
+		data, err := build(config.Complexity{Include: config.StringList{"Query", "User", "_Service"}, Exclude: config.StringList{"User"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Query", "_Service"}, rootNames(data))
+	})
+
+	t.Run("unknown types", func(t *testing.T) {
+		_, err := build(config.Complexity{Include: config.StringList{"Users"}, Exclude: config.StringList{"String"}})
+		require.EqualError(t, err, "complexity: Users is not an object in the schema\ncomplexity: String is not an object in the schema")
+	})
+}
+
+func TestBuildDataPhases(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"Query": {Model: config.StringList{"map[string]interface{}"}},
+	}, "testdata/renamedroots/schema.graphql")
+
+	var phases []config.BuildPhase
+	cfg.OnBuildPhase = func(phase config.BuildPhase) {
+		phases = append(phases, phase)
+	}
+
+	_, err := BuildData(cfg)
+	require.NoError(t, err)
+
+	var names []string
+	for _, phase := range phases {
+		names = append(names, phase.Name)
+	}
+	require.Equal(t, []string{"load schema", "autobind", "bind", "directives", "types", "introspection", "references"}, names)
+	require.NotZero(t, phases[0].Count)
+	require.Equal(t, phases[0].Count, phases[4].Count)
+}
+
+func TestBuildDataAmbiguousFieldMatching(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"User": {Model: config.StringList{"github.com/99designs/gqlgen/codegen/testdata/fieldmatching.User"}},
+	}, "testdata/fieldmatching/schema.graphql")
+	cfg.FieldMatching = []config.FieldMatching{config.FieldMatchingSnake}
+
+	_, err := BuildData(cfg)
+	require.EqualError(t, err, "unable to build object definition: testdata/fieldmatching/schema.graphql:6: User.userId: matches more than one go field with snake field matching: UserId, User_ID")
+
+	cfg.FieldMatching = []config.FieldMatching{config.FieldMatchingCamel, config.FieldMatchingSnake}
+	data, err := BuildData(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "UserId", data.ObjectByName("User").Fields[0].GoFieldName)
+}
+
+func TestBuildDataInputModel(t *testing.T) {
+	const pkg = "github.com/99designs/gqlgen/codegen/testdata/inputmodel"
+	newConfig := func(account string) *config.Config {
+		cfg := testConfig(config.TypeMap{
+			"Query":   {Model: config.StringList{"map[string]interface{}"}},
+			"Money":   {Model: config.StringList{pkg + ".Money"}, InputModel: config.StringList{pkg + ".MoneyInput"}},
+			"Account": {Model: config.StringList{pkg + "." + account}},
+			"Filter":  {Model: config.StringList{pkg + ".Filter"}},
+		}, "testdata/inputmodel/schema.graphql")
+		return cfg
+	}
+
+	data, err := BuildData(newConfig("Account"))
+	require.NoError(t, err)
+
+	balance := data.ObjectByName("Account").Fields[0].TypeReference
+	require.Equal(t, pkg+".Money", balance.GO.String())
+	require.False(t, balance.InputOnly)
+	require.Equal(t, "marshalNMoney2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestdataᚋinputmodelᚐMoney", balance.MarshalFunc())
+
+	min := data.ObjectByName("Query").Fields[0].Args[0].TypeReference
+	require.Equal(t, "*"+pkg+".MoneyInput", min.GO.String())
+	require.True(t, min.InputOnly)
+	require.Equal(t, "", min.MarshalFunc())
+
+	above := data.InputByName("Filter").Fields[0].TypeReference
+	require.Equal(t, "*"+pkg+".MoneyInput", above.GO.String())
+	require.True(t, above.InputOnly)
+
+	_, err = BuildData(newConfig("BadAccount"))
+	require.EqualError(t, err, "unable to build object definition: testdata/inputmodel/schema.graphql:6: Account.balance: Money is bound to "+pkg+".MoneyInput, which is its input_model and can only be used for inputs")
+
+	cfg := newConfig("Account")
+	cfg.Models["Filter"] = config.TypeMapEntry{Model: config.StringList{pkg + ".Filter"}, InputModel: config.StringList{pkg + ".Filter"}}
+	_, err = BuildData(cfg)
+	require.EqualError(t, err, "model Filter: input_model can only be used for scalars, not INPUT_OBJECT")
+}
+
+func TestBuildDataRenamedRoots(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"Query": {Model: config.StringList{"map[string]interface{}"}},
+	}, "testdata/renamedroots/schema.graphql")
+
+	data, err := BuildData(cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, "RootQuery", data.QueryRoot.Name)
+	require.Equal(t, "RootMutation", data.MutationRoot.Name)
+	require.Equal(t, "RootSubscription", data.SubscriptionRoot.Name)
+	require.True(t, data.QueryRoot.Root)
+	require.True(t, data.MutationRoot.DisableConcurrency)
+	require.True(t, data.SubscriptionRoot.Stream)
+	require.Contains(t, fieldNames(data.QueryRoot), "__schema")
+
+	query := data.ObjectByName("Query")
+	require.NotNil(t, query)
+	require.False(t, query.Root)
+	require.Equal(t, []string{"name"}, fieldNames(query))
+}
+
+func TestBuildDataSources(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"User": {Model: config.StringList{"map[string]interface{}"}},
+	}, "testdata/sources/schema.graphql", "testdata/sources/user.graphql")
+
+	data, err := BuildData(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, data.Sources, 2)
+	for i, filename := range cfg.SchemaFilename {
+		raw, err := ioutil.ReadFile(filename)
+		require.NoError(t, err)
+
+		require.Equal(t, filename, data.Sources[i].Name)
+		require.Equal(t, string(raw), data.Sources[i].Input)
+		require.Equal(t, data.SchemaStr[filename], data.Sources[i].Input)
+	}
+}
+
+func TestBuildPartialData(t *testing.T) {
+	cfg := testConfig(config.TypeMap{
+		"User": {Model: config.StringList{"map[string]interface{}"}},
+		"Post": {Model: config.StringList{"map[string]interface{}"}},
+	}, "testdata/builderrors/schema.graphql")
+
+	data, err := BuildPartialData(cfg, func(def *ast.Definition) bool {
+		return def.Name == "User"
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, data.ObjectByName("User"))
+	require.Nil(t, data.ObjectByName("Post"))
+	require.NotNil(t, data.ObjectByName("__Type"))
+	require.Equal(t, []string{"user", "post", "__type", "__schema"}, fieldNames(data.QueryRoot))
+}
+
+func fieldNames(obj *Object) []string {
+	var names []string
+	for _, f := range obj.Fields {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func BenchmarkBuildData(b *testing.B) {
+	dir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(b, err)
+	defer os.RemoveAll(dir)
+
+	var schema bytes.Buffer
+	models := config.TypeMap{}
+	schema.WriteString("type Query {\n")
+	for i := 0; i < 1500; i++ {
+		fmt.Fprintf(&schema, "  type%d(id: ID!): Type%d\n", i, i)
+	}
+	schema.WriteString("}\n")
+	for i := 0; i < 1500; i++ {
+		fmt.Fprintf(&schema, "type Type%d {\n  id: ID!\n  name: String\n  next: Type%d\n  tags(first: Int = 10): [String!]!\n}\n", i, (i+1)%1500)
+		models[fmt.Sprintf("Type%d", i)] = config.TypeMapEntry{Model: config.StringList{"map[string]interface{}"}}
+	}
+	require.NoError(b, ioutil.WriteFile(filepath.Join(dir, "schema.graphql"), schema.Bytes(), 0644))
+
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	defer func(workers int) { buildWorkers = workers }(buildWorkers)
+
+	for _, workers := range []int{1, 0} {
+		name := "sequential"
+		if workers == 0 {
+			name = "concurrent"
+		}
+		b.Run(name, func(b *testing.B) {
+			buildWorkers = workers
+			for i := 0; i < b.N; i++ {
+				cfg := testConfig(config.TypeMap{}, filepath.Join(dir, "schema.graphql"))
+				for name, entry := range models {
+					cfg.Models[name] = entry
+				}
+
+				_, err := BuildData(cfg)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func TestDataByName(t *testing.T) {
+	user := &Object{Definition: &ast.Definition{Name: "User"}}
+	post := &Object{Definition: &ast.Definition{Name: "Post"}}
+	filter := &Object{Definition: &ast.Definition{Name: "Filter"}}
+
+	data := Data{
+		Objects: Objects{user},
+		Inputs:  Objects{filter},
+	}
+	data.indexObjects()
+
+	require.Equal(t, user, data.ObjectByName("User"))
+	require.Nil(t, data.ObjectByName("user"))
+	require.Nil(t, data.ObjectByName("Filter"))
+	require.Equal(t, filter, data.InputByName("Filter"))
+	require.Nil(t, data.InputByName("User"))
+
+	t.Run("finds objects added after indexing", func(t *testing.T) {
+		data.Objects = append(data.Objects, post)
+		require.Equal(t, post, data.ObjectByName("Post"))
+	})
+}
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -19,21 +20,31 @@ type Directive struct {
 func (b *builder) buildDirectives() (map[string]*Directive, error) {
 	directives := make(map[string]*Directive, len(b.Schema.Directives))
 
-	for name, dir := range b.Schema.Directives {
+	var errs BuildErrors
+	names := make([]string, 0, len(b.Schema.Directives))
+	for name := range b.Schema.Directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dir := b.Schema.Directives[name]
 		if _, ok := directives[name]; ok {
-			return nil, errors.Errorf("directive with name %s already exists", name)
+			errs = errs.add(errors.Errorf("directive with name %s already exists", name))
+			continue
 		}
 
 		var builtin bool
-		if name == "skip" || name == "include" || name == "deprecated" {
+		if name == "skip" || name == "include" || name == "deprecated" || name == "goField" {
 			builtin = true
 		}
 
 		var args []*FieldArgument
 		for _, arg := range dir.Arguments {
-			tr, err := b.Binder.TypeReference(arg.Type, nil)
+			tr, err := b.Binder.InputTypeReference(arg.Type, nil)
 			if err != nil {
-				return nil, err
+				errs = errs.addWrapped(err, withPosition(dir.Position, "directive "+name))
+				continue
 			}
 
 			newArg := &FieldArgument{
@@ -46,7 +57,8 @@ func (b *builder) buildDirectives() (map[string]*Directive, error) {
 				var err error
 				newArg.Default, err = arg.DefaultValue.Value(nil)
 				if err != nil {
-					return nil, errors.Errorf("default value for directive argument %s(%s) is not valid: %s", dir.Name, arg.Name, err.Error())
+					errs = errs.add(errors.Errorf("default value for directive argument %s(%s) is not valid: %s", dir.Name, arg.Name, err.Error()))
+					continue
 				}
 			}
 			args = append(args, newArg)
@@ -59,6 +71,9 @@ func (b *builder) buildDirectives() (map[string]*Directive, error) {
 		}
 	}
 
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return directives, nil
 }
 
@@ -150,3 +165,17 @@ func (d *Directive) Declaration() string {
 	res += ") (res interface{}, err error)"
 	return res
 }
+
+// DeprecationReason returns the reason given to @deprecated in directives, falling back to the default reason from the
+// spec, and whether the directive was present at all.
+func DeprecationReason(directives ast.DirectiveList) (string, bool) {
+	d := directives.ForName("deprecated")
+	if d == nil {
+		return "", false
+	}
+
+	if reason := d.Arguments.ForName("reason"); reason != nil && reason.Value != nil && reason.Value.Raw != "" {
+		return reason.Value.Raw, true
+	}
+	return "No longer supported", true
+}
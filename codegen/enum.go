@@ -0,0 +1,41 @@
+package codegen
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser/ast"
+)
+
+type Enum struct {
+	*ast.Definition
+	Values []*EnumValue
+}
+
+type EnumValue struct {
+	*ast.EnumValueDefinition
+	Directives        []*Directive
+	IsDeprecated      bool
+	DeprecationReason string
+}
+
+func (b *builder) buildEnum(typ *ast.Definition) (*Enum, error) {
+	e := &Enum{
+		Definition: typ,
+	}
+
+	for _, value := range typ.EnumValues {
+		dirs, err := b.getDirectives(value.Directives)
+		if err != nil {
+			return nil, errors.Wrap(err, withPosition(value.Position, typ.Name+"."+value.Name))
+		}
+
+		v := &EnumValue{
+			EnumValueDefinition: value,
+			Directives:          dirs,
+		}
+		v.DeprecationReason, v.IsDeprecated = DeprecationReason(value.Directives)
+
+		e.Values = append(e.Values, v)
+	}
+
+	return e, nil
+}
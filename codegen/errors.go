@@ -0,0 +1,48 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// BuildErrors collects every problem found while building the data model, so they can all be reported by a single
+// generate run instead of one at a time.
+type BuildErrors []error
+
+func (errs BuildErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// add appends err to the list, flattening any nested BuildErrors.
+func (errs BuildErrors) add(err error) BuildErrors {
+	if nested, ok := err.(BuildErrors); ok {
+		return append(errs, nested...)
+	}
+	return append(errs, err)
+}
+
+// addWrapped is like add, but wraps each of the appended errors with message.
+func (errs BuildErrors) addWrapped(err error, message string) BuildErrors {
+	if nested, ok := err.(BuildErrors); ok {
+		for _, err := range nested {
+			errs = append(errs, errors.Wrap(err, message))
+		}
+		return errs
+	}
+	return append(errs, errors.Wrap(err, message))
+}
+
+// withPosition prefixes name with the file and line it was declared at in the schema, if known.
+func withPosition(pos *ast.Position, name string) string {
+	if pos == nil || pos.Src == nil {
+		return name
+	}
+	return fmt.Sprintf("%s:%d: %s", pos.Src.Name, pos.Line, name)
+}
@@ -28,12 +28,16 @@ type Field struct {
 	Object           *Object          // A link back to the parent object
 	Default          interface{}      // The default value
 	Directives       []*Directive
+
+	IsDeprecated      bool   // Is this field marked with @deprecated
+	DeprecationReason string // The reason given by @deprecated, if any
 }
 
 func (b *builder) buildField(obj *Object, field *ast.FieldDefinition) (*Field, error) {
+	var errs BuildErrors
 	dirs, err := b.getDirectives(field.Directives)
 	if err != nil {
-		return nil, err
+		errs = errs.add(err)
 	}
 
 	f := Field{
@@ -44,26 +48,44 @@ func (b *builder) buildField(obj *Object, field *ast.FieldDefinition) (*Field, e
 		GoFieldType:     GoFieldVariable,
 		GoReceiverName:  "obj",
 	}
+	f.DeprecationReason, f.IsDeprecated = DeprecationReason(field.Directives)
 
 	if field.DefaultValue != nil {
 		var err error
 		f.Default, err = field.DefaultValue.Value(nil)
 		if err != nil {
-			return nil, errors.Errorf("default value %s is not valid: %s", field.Name, err.Error())
+			errs = errs.add(errors.Errorf("default value %s is not valid: %s", field.Name, err.Error()))
 		}
 	}
 
 	for _, arg := range field.Arguments {
 		newArg, err := b.buildArg(obj, arg)
 		if err != nil {
-			return nil, err
+			errs = errs.addWrapped(err, arg.Name)
+			continue
 		}
 		f.Args = append(f.Args, newArg)
 	}
 
 	if err = b.bindField(obj, &f); err != nil {
-		f.IsResolver = true
-		log.Println(err.Error())
+		switch err.(type) {
+		case *ambiguousFieldError, *config.InputOnlyError:
+			errs = errs.add(err)
+		default:
+			f.IsResolver = true
+			log.Println(err.Error())
+		}
+	}
+
+	if f.TypeReference == nil {
+		f.TypeReference, err = b.fieldTypeReference(obj, f.Type, nil)
+		if err != nil {
+			errs = errs.add(err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
 	if f.IsResolver && !f.TypeReference.IsPtr() && f.TypeReference.IsStruct() {
@@ -73,17 +95,15 @@ func (b *builder) buildField(obj *Object, field *ast.FieldDefinition) (*Field, e
 	return &f, nil
 }
 
-func (b *builder) bindField(obj *Object, f *Field) error {
-	defer func() {
-		if f.TypeReference == nil {
-			tr, err := b.Binder.TypeReference(f.Type, nil)
-			if err != nil {
-				panic(err)
-			}
-			f.TypeReference = tr
-		}
-	}()
+// fieldTypeReference binds the type of a field, fields of input objects are inputs and use the input_model of scalars.
+func (b *builder) fieldTypeReference(obj *Object, t *ast.Type, bindTarget types.Type) (*config.TypeReference, error) {
+	if obj.Definition.Kind == ast.InputObject {
+		return b.Binder.InputTypeReference(t, bindTarget)
+	}
+	return b.Binder.TypeReference(t, bindTarget)
+}
 
+func (b *builder) bindField(obj *Object, f *Field) error {
 	switch {
 	case f.Name == "__schema":
 		f.GoFieldType = GoFieldMethod
@@ -98,7 +118,7 @@ func (b *builder) bindField(obj *Object, f *Field) error {
 	case obj.Root:
 		f.IsResolver = true
 		return nil
-	case b.Config.Models[obj.Name].Fields[f.Name].Resolver:
+	case b.Config.Models[obj.Name].Fields[f.Name].Resolver, forcesResolver(f.FieldDefinition):
 		f.IsResolver = true
 		return nil
 	case obj.Type == config.MapType:
@@ -150,7 +170,7 @@ func (b *builder) bindField(obj *Object, f *Field) error {
 		}
 
 		result := sig.Results().At(0)
-		tr, err := b.Binder.TypeReference(f.Type, result.Type())
+		tr, err := b.fieldTypeReference(obj, f.Type, result.Type())
 		if err != nil {
 			return err
 		}
@@ -164,7 +184,7 @@ func (b *builder) bindField(obj *Object, f *Field) error {
 		return nil
 
 	case *types.Var:
-		tr, err := b.Binder.TypeReference(f.Type, target.Type())
+		tr, err := b.fieldTypeReference(obj, f.Type, target.Type())
 		if err != nil {
 			return err
 		}
@@ -181,6 +201,21 @@ func (b *builder) bindField(obj *Object, f *Field) error {
 	}
 }
 
+// forcesResolver reports whether the field is marked with @goField(forceResolver: true), which generates a resolver for it
+// even when the model has something it could be bound to.
+func forcesResolver(field *ast.FieldDefinition) bool {
+	dir := field.Directives.ForName("goField")
+	if dir == nil {
+		return false
+	}
+	arg := dir.Arguments.ForName("forceResolver")
+	if arg == nil {
+		return false
+	}
+	val, err := arg.Value.Value(nil)
+	return err == nil && val == true
+}
+
 // findField attempts to match the name to a struct field with the following
 // priorites:
 // 1. Any method with a matching name
@@ -218,7 +253,7 @@ func (b *builder) findBindStructTarget(strukt *types.Struct, name string) (types
 				continue
 			}
 			tags := reflect.StructTag(strukt.Tag(i))
-			if val, ok := tags.Lookup(b.Config.StructTag); ok && equalFieldName(val, name) {
+			if val, ok := tags.Lookup(b.Config.StructTag); ok && equalFieldName(tagName(val), name) {
 				if foundField != nil {
 					return nil, errors.Errorf("tag %s is ambigious; multiple fields have the same tag value of %s", b.Config.StructTag, val)
 				}
@@ -232,13 +267,37 @@ func (b *builder) findBindStructTarget(strukt *types.Struct, name string) (types
 	}
 
 	// Then matching field names
-	for i := 0; i < strukt.NumFields(); i++ {
-		field := strukt.Field(i)
-		if !field.Exported() {
-			continue
+	if len(b.Config.FieldMatching) == 0 {
+		for i := 0; i < strukt.NumFields(); i++ {
+			field := strukt.Field(i)
+			if !field.Exported() {
+				continue
+			}
+			if equalFieldName(field.Name(), name) { // aqui!
+				return field, nil
+			}
 		}
-		if equalFieldName(field.Name(), name) { // aqui!
-			return field, nil
+	}
+
+	// or with the configured field matching, in order
+	for _, matching := range b.Config.FieldMatching {
+		var found []string
+		var foundField *types.Var
+		for i := 0; i < strukt.NumFields(); i++ {
+			field := strukt.Field(i)
+			if !field.Exported() {
+				continue
+			}
+			if fieldMatchers[matching](field, reflect.StructTag(strukt.Tag(i)), name) {
+				found = append(found, field.Name())
+				foundField = field
+			}
+		}
+		if len(found) > 1 {
+			return nil, &ambiguousFieldError{matching: matching, fields: found}
+		}
+		if foundField != nil {
+			return foundField, nil
 		}
 	}
 
@@ -283,6 +342,33 @@ func (b *builder) findBindStructTarget(strukt *types.Struct, name string) (types
 	return nil, nil
 }
 
+var fieldMatchers = map[config.FieldMatching]func(field *types.Var, tags reflect.StructTag, name string) bool{
+	config.FieldMatchingExact: func(field *types.Var, tags reflect.StructTag, name string) bool {
+		return field.Name() == name
+	},
+	config.FieldMatchingCamel: func(field *types.Var, tags reflect.StructTag, name string) bool {
+		return strings.EqualFold(field.Name(), name)
+	},
+	config.FieldMatchingSnake: func(field *types.Var, tags reflect.StructTag, name string) bool {
+		return equalFieldName(field.Name(), name)
+	},
+	config.FieldMatchingJSONTag: func(field *types.Var, tags reflect.StructTag, name string) bool {
+		val, ok := tags.Lookup("json")
+		return ok && tagName(val) != "-" && equalFieldName(tagName(val), name)
+	},
+}
+
+// ambiguousFieldError is returned when more than one go field matches a schema field with the configured field_matching.
+// Unlike other binding problems it fails generation rather than falling back to a resolver.
+type ambiguousFieldError struct {
+	matching config.FieldMatching
+	fields   []string
+}
+
+func (e *ambiguousFieldError) Error() string {
+	return fmt.Sprintf("matches more than one go field with %s field matching: %s", e.matching, strings.Join(e.fields, ", "))
+}
+
 func (f *Field) HasDirectives() bool {
 	return len(f.Directives) > 0
 }
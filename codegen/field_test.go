@@ -28,6 +28,10 @@ type Tags struct {
 	Bar string ` + "`" + `gqlgen:"foo"` + "`" + `
 	Foo int    ` + "`" + `gqlgen:"bar"` + "`" + `
 }
+type Opts struct {
+	Bar string ` + "`" + `gqlgen:"foo,omitempty"` + "`" + `
+	Foo int
+}
 type Amb struct {
 	Bar string ` + "`" + `gqlgen:"foo"` + "`" + `
 	Foo int    ` + "`" + `gqlgen:"foo"` + "`" + `
@@ -43,6 +47,7 @@ type Embed struct {
 	std := scope.Lookup("Std").Type().Underlying().(*types.Struct)
 	anon := scope.Lookup("Anon").Type().Underlying().(*types.Struct)
 	tags := scope.Lookup("Tags").Type().Underlying().(*types.Struct)
+	opts := scope.Lookup("Opts").Type().Underlying().(*types.Struct)
 	amb := scope.Lookup("Amb").Type().Underlying().(*types.Struct)
 	embed := scope.Lookup("Embed").Type().Underlying().(*types.Struct)
 
@@ -58,6 +63,7 @@ type Embed struct {
 		{"Finds a field by name when passed tag but tag not used", std, "name", "gqlgen", "Name", false},
 		{"Ignores tags when not passed a tag", tags, "foo", "", "Foo", false},
 		{"Picks field with tag over field name when passed a tag", tags, "foo", "gqlgen", "Bar", false},
+		{"Ignores tag options when matching a tag", opts, "foo", "gqlgen", "Bar", false},
 		{"Errors when ambigious", amb, "foo", "gqlgen", "", true},
 		{"Finds a field that is in embedded struct", anon, "bar", "", "Bar", false},
 		{"Finds field that is not in embedded struct", embed, "test", "", "Test", false},
@@ -76,6 +82,66 @@ type Embed struct {
 	}
 }
 
+func TestFindFieldMatching(t *testing.T) {
+	input := `
+package test
+
+type Row struct {
+	UserId    string
+	User_Name string
+	Created   int    ` + "`" + `json:"created_at"` + "`" + `
+	Skipped   int    ` + "`" + `json:"-"` + "`" + `
+}
+type Amb struct {
+	UserID  string
+	User_ID string
+}
+`
+	scope, err := parseScope(t, input, "test")
+	require.NoError(t, err)
+
+	row := scope.Lookup("Row").Type().Underlying().(*types.Struct)
+	amb := scope.Lookup("Amb").Type().Underlying().(*types.Struct)
+
+	exact := config.FieldMatchingExact
+	camel := config.FieldMatchingCamel
+	snake := config.FieldMatchingSnake
+	jsonTag := config.FieldMatchingJSONTag
+
+	tests := []struct {
+		Name     string
+		Struct   *types.Struct
+		Field    string
+		Matching []config.FieldMatching
+		Expected string
+		Error    string
+	}{
+		{"Exact doesn't ignore case", row, "UserID", []config.FieldMatching{exact}, "", ""},
+		{"Camel ignores case", row, "UserID", []config.FieldMatching{exact, camel}, "UserId", ""},
+		{"Camel doesn't ignore underscores", row, "UserName", []config.FieldMatching{camel}, "", ""},
+		{"Snake ignores underscores", row, "UserName", []config.FieldMatching{camel, snake}, "User_Name", ""},
+		{"JSON tags are matched", row, "CreatedAt", []config.FieldMatching{jsonTag}, "Created", ""},
+		{"JSON tags of - are not matched", row, "-", []config.FieldMatching{jsonTag}, "", ""},
+		{"Earlier matching wins", amb, "UserID", []config.FieldMatching{exact, snake}, "UserID", ""},
+		{"Errors when ambiguous", amb, "UserID", []config.FieldMatching{snake}, "", "matches more than one go field with snake field matching: UserID, User_ID"},
+	}
+
+	for _, tt := range tests {
+		b := builder{Config: &config.Config{FieldMatching: tt.Matching}}
+		field, err := b.findBindStructTarget(tt.Struct, tt.Field)
+		switch {
+		case tt.Error != "":
+			require.EqualError(t, err, tt.Error, tt.Name)
+		case tt.Expected == "":
+			require.NoError(t, err, tt.Name)
+			require.Nil(t, field, tt.Name)
+		default:
+			require.NoError(t, err, tt.Name)
+			require.Equal(t, tt.Expected, field.Name(), tt.Name)
+		}
+	}
+}
+
 func parseScope(t *testing.T, input interface{}, packageName string) (*types.Scope, error) {
 	// test setup to parse the types
 	fset := token.NewFileSet()
@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"github.com/99designs/gqlgen/codegen/config"
 	"github.com/99designs/gqlgen/codegen/templates"
 )
 
@@ -11,5 +12,11 @@ func GenerateCode(data *Data) error {
 		Data:            data,
 		RegionTags:      true,
 		GeneratedHeader: true,
+		Header:          data.Config.Header.String(),
+		TemplateDir:     data.Config.TemplateDir,
+		LocalPrefix:     data.Config.LocalPrefix,
+		SkipFormat:      data.Config.SkipFormat,
+		SplitFiles:      data.Config.Exec.Layout == config.LayoutSplit,
+		Files:           data.Config.Files,
 	})
 }
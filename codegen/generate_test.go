@@ -0,0 +1,223 @@
+package codegen
+
+import (
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/codegen/config"
+	"github.com/99designs/gqlgen/codegen/templates"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	defer func(workers int) { buildWorkers = workers }(buildWorkers)
+
+	// two runs that build the types in a different order
+	buildWorkers = 1
+	sequential := generateTestdataTemp(t)
+
+	buildWorkers = 8
+	concurrent := generateTestdataTemp(t)
+
+	require.Equal(t, string(sequential), string(concurrent))
+}
+
+func TestGenerateSplitLayout(t *testing.T) {
+	outDir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(outDir)
+
+	single := generateTestdata(t, outDir, withLayout(config.LayoutSingleFile))
+	require.Equal(t, []string{"generated.go"}, goFiles(t, outDir))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(outDir, "generated_test.go"), []byte("package generated\n"), 0644))
+
+	split := generateTestdata(t, outDir, withLayout(config.LayoutSplit))
+	require.Equal(t, []string{
+		"generated.go",
+		"generated_args.go",
+		"generated_field.go",
+		"generated_input.go",
+		"generated_interface.go",
+		"generated_object.go",
+		"generated_test.go",
+		"generated_type.go",
+	}, goFiles(t, outDir))
+	require.True(t, len(split) < len(single))
+
+	object, err := ioutil.ReadFile(filepath.Join(outDir, "generated_object.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(object), " object.gotpl ")
+	require.NotContains(t, string(split), "object.gotpl")
+
+	generateTestdata(t, outDir, withLayout(config.LayoutSingleFile))
+	require.Equal(t, []string{"generated.go", "generated_test.go"}, goFiles(t, outDir))
+}
+
+func TestGenerateHeader(t *testing.T) {
+	outDir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(outDir)
+
+	generated := generateTestdata(t, outDir, func(cfg *config.Config) {
+		cfg.Header = config.Header{
+			Lines:           []string{"Copyright Acme", "", "// Licensed under the MIT license"},
+			BuildConstraint: "!codeanalysis",
+			Version:         true,
+			Plugins:         true,
+			PluginNames:     []string{"modelgen", "resolvergen"},
+		}
+	})
+
+	require.True(t, strings.HasPrefix(string(generated), "// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.\n\n"+
+		"// generated by gqlgen "+graphql.Version+" with modelgen, resolvergen\n"+
+		"// Copyright Acme\n"+
+		"//\n"+
+		"// Licensed under the MIT license\n"+
+		"\n"), string(generated[:400]))
+	// newer versions of gofmt add a matching //go:build line
+	require.Contains(t, string(generated), "\n// +build !codeanalysis\n\npackage generated\n")
+
+	ctx := build.Default
+	match, err := ctx.MatchFile(outDir, "generated.go")
+	require.NoError(t, err)
+	require.True(t, match)
+
+	ctx.BuildTags = []string{"codeanalysis"}
+	match, err = ctx.MatchFile(outDir, "generated.go")
+	require.NoError(t, err)
+	require.False(t, match)
+}
+
+func TestGenerateTemplateDir(t *testing.T) {
+	outDir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(outDir)
+	templateDir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(templateDir)
+
+	interfaceTemplate, err := ioutil.ReadFile("interface.gotpl")
+	require.NoError(t, err)
+	override := filepath.Join(templateDir, "interface.gotpl")
+	require.NoError(t, ioutil.WriteFile(override, append(interfaceTemplate, "\n// {{ len .Interfaces }} interfaces\n"...), 0644))
+
+	generated := generateTestdata(t, outDir, func(cfg *config.Config) {
+		cfg.TemplateDir = templateDir
+	})
+	require.Regexp(t, `\n// \d+ interfaces\n`, string(generated))
+	require.Contains(t, string(generated), "func (ec *executionContext) _Query(", "templates that aren't overridden are still used")
+
+	require.NoError(t, ioutil.WriteFile(override, []byte("{{ range .Interfaces }}\n{{ end }}\n{{ end }}\n"), 0644))
+	err = renderTestdata(t, outDir, func(cfg *config.Config) {
+		cfg.TemplateDir = templateDir
+	})
+	require.EqualError(t, err, "codegen: failed to parse "+override+": template: interface.gotpl:3: unexpected {{end}}")
+}
+
+func TestGenerateSkipFormat(t *testing.T) {
+	formattedDir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(formattedDir)
+	skippedDir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(skippedDir)
+
+	generateTestdata(t, formattedDir, withLayout(config.LayoutSplit))
+	generateTestdata(t, skippedDir, func(cfg *config.Config) {
+		cfg.Exec.Layout = config.LayoutSplit
+		cfg.SkipFormat = true
+	})
+
+	imports := func(filename string) []string {
+		file, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.ImportsOnly)
+		require.NoError(t, err)
+
+		var paths []string
+		for _, imp := range file.Imports {
+			paths = append(paths, imp.Path.Value)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	require.Equal(t, goFiles(t, formattedDir), goFiles(t, skippedDir))
+	for _, filename := range goFiles(t, formattedDir) {
+		require.Equal(t, imports(filepath.Join(formattedDir, filename)), imports(filepath.Join(skippedDir, filename)),
+			"the unformatted %s should have the same imports", filename)
+
+		formatted, err := ioutil.ReadFile(filepath.Join(formattedDir, filename))
+		require.NoError(t, err)
+		require.NotContains(t, string(formatted), templates.UnformattedMarker)
+
+		skipped, err := ioutil.ReadFile(filepath.Join(skippedDir, filename))
+		require.NoError(t, err)
+		require.Contains(t, string(skipped), "\n"+templates.UnformattedMarker+" ")
+		_, err = parser.ParseFile(token.NewFileSet(), filename, skipped, 0)
+		require.NoError(t, err)
+	}
+}
+
+func goFiles(t *testing.T, dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	require.NoError(t, err)
+
+	var names []string
+	for _, match := range matches {
+		names = append(names, filepath.Base(match))
+	}
+	return names
+}
+
+// generateTestdataTemp renders the testdata/generate exec into a temporary file and returns its contents
+func generateTestdataTemp(t *testing.T) []byte {
+	outDir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(outDir)
+
+	return generateTestdata(t, outDir, withLayout(config.LayoutSingleFile))
+}
+
+func withLayout(layout config.Layout) func(cfg *config.Config) {
+	return func(cfg *config.Config) {
+		cfg.Exec.Layout = layout
+	}
+}
+
+// generateTestdata renders the testdata/generate exec into outDir, after configure has changed the config, and returns
+// the contents of the main file
+func generateTestdata(t *testing.T, outDir string, configure func(cfg *config.Config)) []byte {
+	require.NoError(t, renderTestdata(t, outDir, configure))
+
+	b, err := ioutil.ReadFile(filepath.Join(outDir, "generated.go"))
+	require.NoError(t, err)
+	return b
+}
+
+// renderTestdata is generateTestdata for tests that expect rendering to fail
+func renderTestdata(t *testing.T, outDir string, configure func(cfg *config.Config)) error {
+	const models = "github.com/99designs/gqlgen/codegen/testdata/generate"
+	cfg := testConfig(config.TypeMap{
+		"Node":   {Model: config.StringList{models + ".Node"}},
+		"Result": {Model: config.StringList{models + ".Result"}},
+		"User":   {Model: config.StringList{models + ".User"}},
+		"Post":   {Model: config.StringList{models + ".Post"}},
+		"Filter": {Model: config.StringList{models + ".Filter"}},
+		"Role":   {Model: config.StringList{models + ".Role"}},
+	}, "testdata/generate/schema.graphql")
+	cfg.Exec = config.PackageConfig{Filename: filepath.Join(outDir, "generated.go"), Package: "generated"}
+
+	data, err := BuildData(cfg)
+	require.NoError(t, err)
+
+	configure(data.Config)
+	return GenerateCode(data)
+}
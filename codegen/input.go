@@ -0,0 +1,82 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+type inputEdge struct {
+	input string
+	field *ast.FieldDefinition
+}
+
+// checkInputCycles reports input types that require themselves through a chain of non-null fields. Such an input can
+// never be constructed, whereas a cycle broken by a nullable or list field is a legal recursive input.
+func checkInputCycles(schema *ast.Schema) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var errs BuildErrors
+	state := map[string]int{}
+	var path []inputEdge
+
+	var visit func(def *ast.Definition)
+	visit = func(def *ast.Definition) {
+		state[def.Name] = visiting
+		for _, field := range def.Fields {
+			if !field.Type.NonNull || field.Type.Elem != nil {
+				continue
+			}
+			next := schema.Types[field.Type.Name()]
+			if next == nil || next.Kind != ast.InputObject {
+				continue
+			}
+
+			path = append(path, inputEdge{input: def.Name, field: field})
+			switch state[next.Name] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				errs = append(errs, inputCycleError(path, next.Name))
+			}
+			path = path[:len(path)-1]
+		}
+		state[def.Name] = visited
+	}
+
+	for _, name := range sortedTypeNames(schema) {
+		def := schema.Types[name]
+		if def.Kind == ast.InputObject && state[name] == unvisited {
+			visit(def)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func inputCycleError(path []inputEdge, to string) error {
+	start := 0
+	for i, edge := range path {
+		if edge.input == to {
+			start = i
+			break
+		}
+	}
+
+	var steps []string
+	for _, edge := range path[start:] {
+		steps = append(steps, edge.input+"."+edge.field.Name)
+	}
+
+	first := path[start]
+	return fmt.Errorf("%s: input can never be constructed, non-null fields form a cycle: %s -> %s",
+		withPosition(first.field.Position, first.input+"."+first.field.Name), strings.Join(steps, " -> "), to)
+}
@@ -3,6 +3,7 @@ package codegen
 import (
 	"go/types"
 
+	"github.com/pkg/errors"
 	"github.com/vektah/gqlparser/ast"
 )
 
@@ -20,10 +21,10 @@ type InterfaceImplementor struct {
 	Type      types.Type
 }
 
-func (b *builder) buildInterface(typ *ast.Definition) *Interface {
+func (b *builder) buildInterface(typ *ast.Definition) (*Interface, error) {
 	obj, err := b.Binder.DefaultUserObject(typ.Name)
 	if err != nil {
-		panic(err)
+		return nil, errors.Wrap(err, withPosition(typ.Position, typ.Name))
 	}
 
 	i := &Interface{
@@ -35,7 +36,7 @@ func (b *builder) buildInterface(typ *ast.Definition) *Interface {
 	for _, implementor := range b.Schema.GetPossibleTypes(typ) {
 		obj, err := b.Binder.DefaultUserObject(implementor.Name)
 		if err != nil {
-			panic(err)
+			return nil, errors.Wrap(err, withPosition(implementor.Position, typ.Name+" implementor "+implementor.Name))
 		}
 
 		i.Implementors = append(i.Implementors, InterfaceImplementor{
@@ -45,7 +46,7 @@ func (b *builder) buildInterface(typ *ast.Definition) *Interface {
 		})
 	}
 
-	return i
+	return i, nil
 }
 
 func (i *InterfaceImplementor) ValueReceiver() bool {
@@ -0,0 +1,77 @@
+package codegen
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// checkNullability compares the nullability and list nesting of a bound field against its go type. Objects can't return
+// nil from a go pointer for a non-null field, and inputs can't hold the null sent for a nullable field in a go value.
+// Mismatches are only logged unless nullability_strict is set.
+func (b *builder) checkNullability(obj *Object, f *Field) error {
+	if f.IsResolver || f.GoFieldType == GoFieldMap || f.TypeReference == nil || strings.HasPrefix(obj.Name, "__") {
+		return nil
+	}
+	if b.Config.Models[obj.Name].Fields[f.Name].IgnoreNullability {
+		return nil
+	}
+
+	expected, ok := expectedNullability(f.Type, f.TypeReference.GO, obj.Kind == ast.InputObject)
+	if ok {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%s is %s but bound to %s; use %s or change the Go field",
+		withPosition(f.Position, obj.Name+"."+f.Name),
+		f.Type.String(),
+		types.TypeString(f.TypeReference.GO, nil),
+		expected.String(),
+	)
+}
+
+// expectedNullability walks the schema type and go type together, returning false along with the schema type that
+// would match the go type if they disagree at any level of list nesting.
+func expectedNullability(gql *ast.Type, goType types.Type, input bool) (*ast.Type, bool) {
+	expected := *gql
+	ok := true
+
+	if ptr, isPtr := goType.(*types.Pointer); isPtr {
+		if gql.NonNull && !input {
+			expected.NonNull = false
+			ok = false
+		}
+		goType = ptr.Elem()
+	} else if !gql.NonNull && input && !isNilable(goType) {
+		expected.NonNull = true
+		ok = false
+	}
+
+	if gql.Elem != nil {
+		var elem types.Type
+		switch t := goType.Underlying().(type) {
+		case *types.Slice:
+			elem = t.Elem()
+		case *types.Array:
+			elem = t.Elem()
+		}
+		if elem != nil {
+			var elemOk bool
+			expected.Elem, elemOk = expectedNullability(gql.Elem, elem, input)
+			ok = ok && elemOk
+		}
+	}
+
+	return &expected, ok
+}
+
+func isNilable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface, *types.Chan, *types.Signature:
+		return true
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"go/types"
+	"log"
 	"strconv"
 	"strings"
 	"unicode"
@@ -31,12 +32,15 @@ type Object struct {
 	DisableConcurrency bool
 	Stream             bool
 	Directives         []*Directive
+
+	// index is the ByName lookup of the Objects this object was indexed in
+	index *objectIndex
 }
 
 func (b *builder) buildObject(typ *ast.Definition) (*Object, error) {
 	dirs, err := b.getDirectives(typ.Directives)
 	if err != nil {
-		return nil, errors.Wrap(err, typ.Name)
+		return nil, errors.Wrap(err, withPosition(typ.Position, typ.Name))
 	}
 
 	obj := &Object{
@@ -55,7 +59,7 @@ func (b *builder) buildObject(typ *ast.Definition) (*Object, error) {
 	if !obj.Root {
 		goObject, err := b.Binder.DefaultUserObject(typ.Name)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, withPosition(typ.Position, typ.Name))
 		}
 		obj.Type = goObject
 	}
@@ -64,6 +68,7 @@ func (b *builder) buildObject(typ *ast.Definition) (*Object, error) {
 		obj.Implements = append(obj.Implements, b.Schema.Types[intf.Name])
 	}
 
+	var errs BuildErrors
 	for _, field := range typ.Fields {
 		if strings.HasPrefix(field.Name, "__") {
 			continue
@@ -72,12 +77,24 @@ func (b *builder) buildObject(typ *ast.Definition) (*Object, error) {
 		var f *Field
 		f, err = b.buildField(obj, field)
 		if err != nil {
-			return nil, err
+			errs = errs.addWrapped(err, withPosition(field.Position, typ.Name+"."+field.Name))
+			continue
+		}
+		if err = b.checkNullability(obj, f); err != nil {
+			if b.Config.NullabilityStrict {
+				errs = errs.add(err)
+				continue
+			}
+			log.Println(err.Error())
 		}
 
 		obj.Fields = append(obj.Fields, f)
 	}
 
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
 	return obj, nil
 }
 
@@ -152,7 +169,38 @@ func (o *Object) Description() string {
 	return o.Definition.Description
 }
 
+// objectIndex maps lowercased schema names to their position in the Objects it was built from
+type objectIndex struct {
+	size   int
+	byName map[string]int
+}
+
+// index builds the lookup ByName uses. It is only trusted while the Objects are the length they were indexed at,
+// anything appended later is found by scanning until the next index.
+func (os Objects) index() {
+	idx := &objectIndex{size: len(os), byName: make(map[string]int, len(os))}
+	for i, o := range os {
+		// the first match wins, same as scanning
+		key := strings.ToLower(o.Definition.Name)
+		if _, ok := idx.byName[key]; !ok {
+			idx.byName[key] = i
+		}
+		o.index = idx
+	}
+}
+
 func (os Objects) ByName(name string) *Object {
+	if len(os) > 0 && os[0].index != nil && os[0].index.size == len(os) {
+		i, ok := os[0].index.byName[strings.ToLower(name)]
+		if !ok {
+			return nil
+		}
+		// objects moved since indexing, eg by a sort, aren't where the index says so those are scanned for
+		if strings.EqualFold(os[i].Definition.Name, name) {
+			return os[i]
+		}
+	}
+
 	for i, o := range os {
 		if strings.EqualFold(o.Definition.Name, name) {
 			return os[i]
@@ -161,6 +209,17 @@ func (os Objects) ByName(name string) *Object {
 	return nil
 }
 
+// ByNameExact finds an object by its exact schema name. ByName ignores case, which can find the wrong object when
+// two type names only differ in case, graphql type names are case sensitive.
+func (os Objects) ByNameExact(name string) *Object {
+	for i, o := range os {
+		if o.Definition.Name == name {
+			return os[i]
+		}
+	}
+	return nil
+}
+
 func ucFirst(s string) string {
 	if s == "" {
 		return ""
@@ -0,0 +1,54 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestObjectsByName(t *testing.T) {
+	user := &Object{Definition: &ast.Definition{Name: "User"}}
+	lowerUser := &Object{Definition: &ast.Definition{Name: "user"}}
+	post := &Object{Definition: &ast.Definition{Name: "Post"}}
+
+	t.Run("fold", func(t *testing.T) {
+		objects := Objects{user, post}
+
+		require.Equal(t, user, objects.ByName("User"))
+		require.Equal(t, user, objects.ByName("user"))
+		require.Equal(t, post, objects.ByName("POST"))
+		require.Nil(t, objects.ByName("Comment"))
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		objects := Objects{lowerUser, user, post}
+
+		require.Equal(t, user, objects.ByNameExact("User"))
+		require.Equal(t, lowerUser, objects.ByNameExact("user"))
+		require.Nil(t, objects.ByNameExact("post"))
+		require.Nil(t, objects.ByNameExact("Comment"))
+	})
+
+	t.Run("indexed", func(t *testing.T) {
+		objects := Objects{user, post}
+		objects.index()
+
+		require.Equal(t, user, objects.ByName("user"))
+		require.Equal(t, post, objects.ByName("POST"))
+		require.Nil(t, objects.ByName("Comment"))
+
+		comment := &Object{Definition: &ast.Definition{Name: "Comment"}}
+		objects = append(objects, comment)
+		require.Equal(t, comment, objects.ByName("comment"))
+
+		objects.index()
+		objects[0], objects[1] = objects[1], objects[0]
+		require.Equal(t, user, objects.ByName("User"))
+		require.Equal(t, post, objects.ByName("Post"))
+
+		duplicates := Objects{user, lowerUser}
+		duplicates.index()
+		require.Equal(t, user, duplicates.ByName("USER"))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/types"
 	"strconv"
+	"strings"
 
 	"github.com/99designs/gqlgen/internal/code"
 )
@@ -38,6 +39,18 @@ func (s *Imports) String() string {
 	return res
 }
 
+// usedIn is the imports that src refers to by name. It only looks for the name followed by a dot, so an import used
+// in a comment is kept.
+func (s *Imports) usedIn(src string) *Imports {
+	used := &Imports{destDir: s.destDir}
+	for _, imp := range s.imports {
+		if strings.Contains(src, imp.Alias+".") {
+			used.imports = append(used.imports, imp)
+		}
+	}
+	return used
+}
+
 func (s *Imports) Reserve(path string, aliases ...string) (string, error) {
 	if path == "" {
 		panic("empty ambient import")
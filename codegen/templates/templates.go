@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"go/types"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -12,7 +13,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"text/template"
+	"time"
 	"unicode"
 
 	"github.com/99designs/gqlgen/internal/imports"
@@ -28,72 +31,198 @@ type Options struct {
 	RegionTags      bool
 	GeneratedHeader bool
 	Data            interface{}
-	Funcs           template.FuncMap
+
+	// Funcs are added to the built in funcs, see Funcs. It is an error to use the name of a built in.
+	Funcs template.FuncMap
+
+	// Template, if set, is rendered instead of the .gotpl files in the caller's directory
+	Template string
+
+	// TemplateDir, if set, is checked for a file with the same name as each of the caller's templates, eg
+	// object.gotpl, which is used instead. They get the same data and funcs as the templates they replace.
+	TemplateDir string
+
+	// LocalPrefix is a comma separated list of import path prefixes that are grouped after the third party imports,
+	// like goimports -local
+	LocalPrefix string
+
+	// SkipFormat writes the rendered code without running gofmt and pruning the imports, which is much quicker for
+	// large schemas. The file is marked with UnformattedMarker. Setting GQLGEN_SKIP_FORMAT does the same.
+	SkipFormat bool
+
+	// Header is written after the generated header and before the package clause, eg a license or a build
+	// constraint. It should end in a newline.
+	Header string
+
+	// SplitFiles writes each root template into its own file next to Filename, named after the template, instead of
+	// adding them all to Filename. Templates ending in ! are still written to Filename.
+	SplitFiles bool
+
+	// Writer, if set, gets the rendered code instead of it being written to Filename, which is still used to
+	// resolve imports. It can't be used with SplitFiles.
+	Writer io.Writer
+
+	// Files, if set, counts the files written and restores the modification time of the ones it unlinked that are
+	// written unchanged
+	Files *Files
 }
 
+// DryRun, if set, is called with every file Render would write instead of writing it, so the generated code can be
+// looked at without touching disk. Files that would be removed are passed with nil contents.
+var DryRun func(filename string, contents []byte) error
+
+const generatedHeader = "// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.\n\n"
+
+// UnformattedMarker is written into files generated with SkipFormat, so CI can check that they aren't committed
+const UnformattedMarker = "//gqlgen:unformatted"
+
 func Render(cfg Options) error {
 	if CurrentImports != nil {
 		panic(fmt.Errorf("recursive or concurrent call to RenderToFile detected"))
 	}
-	CurrentImports = &Imports{destDir: filepath.Dir(cfg.Filename)}
+
+	if cfg.Writer != nil && cfg.SplitFiles {
+		return errors.New("a Writer can't be used with SplitFiles")
+	}
+	if os.Getenv("GQLGEN_SKIP_FORMAT") != "" {
+		cfg.SkipFormat = true
+	}
 
 	// load path relative to calling source file
 	_, callerFile, _, _ := runtime.Caller(1)
 	rootDir := filepath.Dir(callerFile)
+	// errors say which plugin was rendering, by the name of its directory
+	caller := filepath.Base(rootDir)
 
 	funcs := Funcs()
 	for n, f := range cfg.Funcs {
+		if _, builtin := funcs[n]; builtin {
+			return fmt.Errorf("template func %s is already a built in func", n)
+		}
 		funcs[n] = f
 	}
 	t := template.New("").Funcs(funcs)
 
 	var roots []string
-	// load all the templates in the directory
-	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+	var err error
+	if cfg.Template != "" {
+		t, err = t.New("template.gotpl").Parse(cfg.Template)
+		if err != nil {
+			return errors.Wrapf(err, "%s: failed to parse the template for %s", caller, cfg.Filename)
+		}
+		roots = append(roots, "template.gotpl")
+	} else {
+		roots, err = loadTemplates(t, rootDir, cfg)
 		if err != nil {
+			return errors.Wrap(err, caller)
+		}
+	}
+
+	// then execute all the important looking ones in order, adding them to the same file
+	sort.Slice(roots, func(i, j int) bool {
+		// important files go first
+		if strings.HasSuffix(roots[i], "!.gotpl") {
+			return true
+		}
+		if strings.HasSuffix(roots[j], "!.gotpl") {
+			return false
+		}
+		return roots[i] < roots[j]
+	})
+
+	var filenames []string
+	rootsByFilename := map[string][]string{}
+	for _, root := range roots {
+		filename := cfg.Filename
+		if cfg.SplitFiles && !strings.HasSuffix(root, "!.gotpl") {
+			filename = splitFilename(cfg.Filename, root)
+		}
+		if _, ok := rootsByFilename[filename]; !ok {
+			filenames = append(filenames, filename)
+		}
+		rootsByFilename[filename] = append(rootsByFilename[filename], root)
+	}
+
+	// the imports reserved by the first file are carried over to the rest, unused ones are pruned when writing
+	var reserved []*Import
+	for i, filename := range filenames {
+		imports := &Imports{imports: append([]*Import(nil), reserved...), destDir: filepath.Dir(filename)}
+		if err = renderFile(t, cfg, caller, filename, rootsByFilename[filename], imports); err != nil {
 			return err
 		}
-		name := filepath.ToSlash(strings.TrimPrefix(path, rootDir+string(os.PathSeparator)))
+		if i == 0 {
+			reserved = imports.imports
+		}
+	}
+
+	if cfg.Writer != nil {
+		return nil
+	}
+
+	// clean up anything left behind by a previous run with a different layout
+	for _, root := range roots {
+		filename := splitFilename(cfg.Filename, root)
+		if _, written := rootsByFilename[filename]; !written {
+			if err = removeGenerated(filename); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadTemplates parses all of the templates in dir into t, returning their names. Templates in cfg.TemplateDir
+// with the same name are parsed instead.
+func loadTemplates(t *template.Template, dir string, cfg Options) ([]string, error) {
+	var roots []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Wrap(err, "locating templates")
+		}
+		name := filepath.ToSlash(strings.TrimPrefix(path, dir+string(os.PathSeparator)))
 		if !strings.HasSuffix(info.Name(), ".gotpl") {
 			return nil
 		}
+
+		if cfg.TemplateDir != "" {
+			override := filepath.Join(cfg.TemplateDir, filepath.FromSlash(name))
+			if _, err := os.Stat(override); err == nil {
+				path = override
+			} else if !os.IsNotExist(err) {
+				return err
+			}
+		}
+
 		b, err := ioutil.ReadFile(path)
 		if err != nil {
 			return err
 		}
 
-		t, err = t.New(name).Parse(string(b))
+		_, err = t.New(name).Parse(string(b))
 		if err != nil {
-			return errors.Wrap(err, cfg.Filename)
+			return errors.Wrapf(err, "failed to parse %s", path)
 		}
 
 		roots = append(roots, name)
 
 		return nil
 	})
-	if err != nil {
-		return errors.Wrap(err, "locating templates")
-	}
+	return roots, err
+}
+
+func renderFile(t *template.Template, cfg Options, caller string, filename string, roots []string, imports *Imports) error {
+	CurrentImports = imports
+	defer func() { CurrentImports = nil }()
 
-	// then execute all the important looking ones in order, adding them to the same file
-	sort.Slice(roots, func(i, j int) bool {
-		// important files go first
-		if strings.HasSuffix(roots[i], "!.gotpl") {
-			return true
-		}
-		if strings.HasSuffix(roots[j], "!.gotpl") {
-			return false
-		}
-		return roots[i] < roots[j]
-	})
 	var buf bytes.Buffer
 	for _, root := range roots {
 		if cfg.RegionTags {
 			buf.WriteString("\n// region    " + center(70, "*", " "+root+" ") + "\n")
 		}
-		err = t.Lookup(root).Execute(&buf, cfg.Data)
+		err := t.Lookup(root).Execute(&buf, cfg.Data)
 		if err != nil {
-			return errors.Wrap(err, root)
+			return errors.Wrapf(err, "%s: failed to render %s", caller, filename)
 		}
 		if cfg.RegionTags {
 			buf.WriteString("\n// endregion " + center(70, "*", " "+root+" ") + "\n")
@@ -102,21 +231,59 @@ func Render(cfg Options) error {
 
 	var result bytes.Buffer
 	if cfg.GeneratedHeader {
-		result.WriteString("// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.\n\n")
+		result.WriteString(generatedHeader)
+	}
+	if cfg.Header != "" {
+		result.WriteString(cfg.Header)
+		result.WriteString("\n")
+	}
+	if cfg.SkipFormat {
+		result.WriteString(UnformattedMarker + " formatting was skipped, regenerate this file before committing it\n\n")
+		// there is no pruning to remove the imports that weren't used, so leave out the ones that obviously aren't
+		imports = imports.usedIn(buf.String())
 	}
 	result.WriteString("package ")
 	result.WriteString(cfg.PackageName)
 	result.WriteString("\n\n")
 	result.WriteString("import (\n")
-	result.WriteString(CurrentImports.String())
+	result.WriteString(imports.String())
 	result.WriteString(")\n")
-	_, err = buf.WriteTo(&result)
+	_, err := buf.WriteTo(&result)
 	if err != nil {
 		return err
 	}
-	CurrentImports = nil
 
-	return write(cfg.Filename, result.Bytes())
+	return write(cfg, filename, result.Bytes())
+}
+
+// splitFilename is the file a root template is written to when splitting, eg generated_object.go for object.gotpl
+func splitFilename(filename string, root string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(root, ".gotpl"), "!")
+	name = strings.Replace(name, "/", "_", -1)
+	return strings.TrimSuffix(filename, ".go") + "_" + name + ".go"
+}
+
+// removeGenerated removes filename if it exists and was generated by gqlgen, leaving anything else alone
+func removeGenerated(filename string) error {
+	b, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(b, []byte(generatedHeader)) {
+		return nil
+	}
+	return Remove(filename)
+}
+
+// Remove removes a file that is no longer generated, or passes it to DryRun
+func Remove(filename string) error {
+	if DryRun != nil {
+		return DryRun(filename, nil)
+	}
+	return errors.Wrapf(os.Remove(filename), "failed to remove %s", filename)
 }
 
 func center(width int, pad string, s string) string {
@@ -128,6 +295,8 @@ func center(width int, pad string, s string) string {
 	return strings.Repeat(pad, lpad) + s + strings.Repeat(pad, rpad)
 }
 
+// Funcs are the funcs every template can use. ucFirst, lcFirst, quote, rawQuote, go, goPrivate, reserveImport,
+// lookupImport, ref, add and prefixLines are stable for plugin templates to use, the others may change between releases.
 func Funcs() template.FuncMap {
 	return template.FuncMap{
 		"ucFirst":       ucFirst,
@@ -140,8 +309,8 @@ func Funcs() template.FuncMap {
 		"call":          Call,
 		"prefixLines":   prefixLines,
 		"notNil":        notNil,
-		"reserveImport": CurrentImports.Reserve,
-		"lookupImport":  CurrentImports.Lookup,
+		"reserveImport": reserveImport,
+		"lookupImport":  lookupImport,
 		"go":            ToGo,
 		"goPrivate":     ToGoPrivate,
 		"add": func(a, b int) int {
@@ -153,6 +322,15 @@ func Funcs() template.FuncMap {
 	}
 }
 
+// reserveImport and lookupImport are called through CurrentImports, which is replaced for each file being rendered
+func reserveImport(path string, aliases ...string) (string, error) {
+	return CurrentImports.Reserve(path, aliases...)
+}
+
+func lookupImport(path string) string {
+	return CurrentImports.Lookup(path)
+}
+
 func ucFirst(s string) string {
 	if s == "" {
 		return ""
@@ -522,16 +700,118 @@ func render(filename string, tpldata interface{}) (*bytes.Buffer, error) {
 	return buf, t.Execute(buf, tpldata)
 }
 
-func write(filename string, b []byte) error {
+// WriteStats counts the files Render has written, and the ones it left alone because they were already up to date
+type WriteStats struct {
+	Written   int
+	Unchanged int
+}
+
+// Files tracks the files written by one generate run. Files removed with Unlink get their modification time back if
+// Render writes them again with the same contents, and every file Render writes is counted in Stats.
+type Files struct {
+	Stats WriteStats
+
+	unlinked map[string]unlinkedFile
+}
+
+type unlinkedFile struct {
+	contents []byte
+	modTime  time.Time
+}
+
+// Unlink removes a generated file before it is regenerated, so code left in it can't break loading packages. A nil
+// Files only removes it.
+func (f *Files) Unlink(filename string) {
+	if f != nil {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return
+		}
+		if b, err := ioutil.ReadFile(filename); err == nil {
+			if f.unlinked == nil {
+				f.unlinked = map[string]unlinkedFile{}
+			}
+			f.unlinked[filename] = unlinkedFile{contents: b, modTime: info.ModTime()}
+		}
+	}
+	_ = syscall.Unlink(filename)
+}
+
+// UnlinkGenerated unlinks filename and the files a split layout writes next to it, if gqlgen generated them.
+func (f *Files) UnlinkGenerated(filename string) {
+	f.Unlink(filename)
+
+	split, _ := filepath.Glob(strings.TrimSuffix(filename, ".go") + "_*.go")
+	for _, name := range split {
+		if b, err := ioutil.ReadFile(name); err == nil && bytes.HasPrefix(b, []byte(generatedHeader)) {
+			f.Unlink(name)
+		}
+	}
+}
+
+// written records that filename was written with contents, restoring its modification time if it was unlinked with
+// the same contents
+func (f *Files) written(filename string, contents []byte) error {
+	if f == nil {
+		return nil
+	}
+
+	previous, ok := f.unlinked[filename]
+	delete(f.unlinked, filename)
+	if ok && bytes.Equal(previous.contents, contents) {
+		f.Stats.Unchanged++
+		return errors.Wrapf(os.Chtimes(filename, previous.modTime, previous.modTime), "failed to write %s", filename)
+	}
+
+	f.Stats.Written++
+	return nil
+}
+
+func (f *Files) unchanged() {
+	if f != nil {
+		f.Stats.Unchanged++
+	}
+}
+
+func write(cfg Options, filename string, b []byte) error {
+	formatted := b
+	var pruneErr error
+	if !cfg.SkipFormat {
+		formatted, pruneErr = imports.Prune(filename, b, cfg.LocalPrefix)
+		if pruneErr != nil {
+			fmt.Fprintf(os.Stderr, "gofmt failed on %s: %s\n", filepath.Base(filename), pruneErr.Error())
+			formatted = b
+		}
+	}
+
+	if cfg.Writer != nil {
+		_, err := cfg.Writer.Write(formatted)
+		return err
+	}
+	if DryRun != nil {
+		return DryRun(filename, formatted)
+	}
+
+	// files that haven't changed are left alone, so their modification time doesn't make anything rebuild
+	if existing, err := ioutil.ReadFile(filename); err == nil && bytes.Equal(existing, formatted) {
+		cfg.Files.unchanged()
+		return nil
+	}
+
 	err := os.MkdirAll(filepath.Dir(filename), 0755)
 	if err != nil {
 		return errors.Wrap(err, "failed to create directory")
 	}
 
-	formatted, err := imports.Prune(filename, b)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "gofmt failed on %s: %s\n", filepath.Base(filename), err.Error())
-		formatted = b
+	// the source that couldn't be formatted is kept next to the file, so the broken code can be looked at
+	broken := filename + ".broken"
+	if pruneErr != nil {
+		if err = ioutil.WriteFile(broken, b, 0644); err != nil {
+			return errors.Wrapf(err, "failed to write %s", broken)
+		}
+		fmt.Fprintf(os.Stderr, "the source of %s that failed to format is in %s\n", filepath.Base(filename), broken)
+	} else if err = os.Remove(broken); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove %s", broken)
 	}
 
 	err = ioutil.WriteFile(filename, formatted, 0644)
@@ -539,5 +819,5 @@ func write(filename string, b []byte) error {
 		return errors.Wrapf(err, "failed to write %s", filename)
 	}
 
-	return nil
+	return cfg.Files.written(filename, formatted)
 }
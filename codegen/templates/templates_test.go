@@ -1,7 +1,17 @@
 package templates
 
 import (
+	"bytes"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"text/template"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -100,3 +110,225 @@ func TestCenter(t *testing.T) {
 	require.Equal(t, "##fffff###", center(10, "#", "fffff"))
 	require.Equal(t, "###fffff###", center(11, "#", "fffff"))
 }
+
+func TestRawQuote(t *testing.T) {
+	for _, s := range []string{
+		"type Query { name: String }",
+		"\"\"\" a `backtick` in a description \"\"\"\ntype Query { name: String }",
+		"`",
+		"``",
+	} {
+		tv, err := types.Eval(token.NewFileSet(), nil, token.NoPos, rawQuote(s))
+		require.NoError(t, err)
+		require.Equal(t, s, constant.StringVal(tv.Value))
+	}
+}
+
+const helloTemplate = `{{ reserveImport "fmt" }}
+{{ reserveImport "strings" }}
+
+func Hello() string {
+	return fmt.Sprint({{ .Name | quote }})
+}
+`
+
+func TestRenderWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "hello.go")
+
+	var buf bytes.Buffer
+	err = Render(Options{
+		PackageName: "hello",
+		Filename:    filename,
+		Template:    helloTemplate,
+		Data:        map[string]string{"Name": "world"},
+		Writer:      &buf,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "package hello\n\nimport (\n\t\"fmt\"\n)\n\nfunc Hello() string {\n\treturn fmt.Sprint(\"world\")\n}\n", buf.String())
+
+	_, err = os.Stat(filename)
+	require.True(t, os.IsNotExist(err))
+
+	err = Render(Options{PackageName: "hello", Filename: filename, Writer: &buf, SplitFiles: true})
+	require.EqualError(t, err, "a Writer can't be used with SplitFiles")
+}
+
+func TestRenderDryRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "hello.go")
+
+	// left behind by a previous run with the split layout
+	split := splitFilename(filename, "template.gotpl")
+	require.NoError(t, ioutil.WriteFile(split, []byte(generatedHeader+"package hello\n"), 0644))
+
+	written := map[string][]byte{}
+	DryRun = func(filename string, contents []byte) error {
+		written[filename] = contents
+		return nil
+	}
+	defer func() { DryRun = nil }()
+
+	err = Render(Options{
+		PackageName:     "hello",
+		Filename:        filename,
+		GeneratedHeader: true,
+		Template:        helloTemplate,
+		Data:            map[string]string{"Name": "world"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, written, 2)
+	require.Contains(t, string(written[filename]), `return fmt.Sprint("world")`)
+	require.Nil(t, written[split])
+	require.Contains(t, written, split)
+
+	_, err = os.Stat(filename)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(split)
+	require.NoError(t, err)
+}
+
+func TestRenderFuncs(t *testing.T) {
+	var buf bytes.Buffer
+	err := Render(Options{
+		PackageName: "hello",
+		Filename:    "hello.go",
+		Template:    `const Name = {{ shout .Name | quote }}`,
+		Data:        map[string]string{"Name": "world"},
+		Funcs:       template.FuncMap{"shout": strings.ToUpper},
+		Writer:      &buf,
+	})
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), `const Name = "WORLD"`)
+
+	err = Render(Options{
+		PackageName: "hello",
+		Filename:    "hello.go",
+		Template:    helloTemplate,
+		Funcs:       template.FuncMap{"go": strings.ToUpper},
+		Writer:      &buf,
+	})
+	require.EqualError(t, err, "template func go is already a built in func")
+}
+
+func TestRenderUnchanged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "hello.go")
+
+	files := &Files{}
+	render := func(name string) {
+		err := Render(Options{
+			PackageName: "hello",
+			Filename:    filename,
+			Template:    helloTemplate,
+			Data:        map[string]string{"Name": name},
+			Files:       files,
+		})
+		require.NoError(t, err)
+	}
+	yesterday := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	modTime := func() time.Time {
+		info, err := os.Stat(filename)
+		require.NoError(t, err)
+		return info.ModTime()
+	}
+
+	render("world")
+	require.Equal(t, WriteStats{Written: 1}, files.Stats)
+
+	t.Run("unchanged files aren't written", func(t *testing.T) {
+		require.NoError(t, os.Chtimes(filename, yesterday, yesterday))
+		render("world")
+		require.Equal(t, WriteStats{Written: 1, Unchanged: 1}, files.Stats)
+		require.True(t, modTime().Equal(yesterday))
+	})
+
+	t.Run("unlinked files get their modification time back", func(t *testing.T) {
+		files.Unlink(filename)
+		_, err := os.Stat(filename)
+		require.True(t, os.IsNotExist(err))
+
+		render("world")
+		require.Equal(t, WriteStats{Written: 1, Unchanged: 2}, files.Stats)
+		require.True(t, modTime().Equal(yesterday))
+	})
+
+	t.Run("changed files are written", func(t *testing.T) {
+		files.Unlink(filename)
+		render("moon")
+		require.Equal(t, WriteStats{Written: 2, Unchanged: 2}, files.Stats)
+		require.True(t, modTime().After(yesterday))
+	})
+
+	t.Run("unlinked files are only restored once", func(t *testing.T) {
+		require.NoError(t, os.Chtimes(filename, yesterday, yesterday))
+		files.Unlink(filename)
+		render("moon")
+		require.True(t, modTime().Equal(yesterday))
+
+		require.NoError(t, os.Remove(filename))
+		render("moon")
+		require.Equal(t, WriteStats{Written: 3, Unchanged: 3}, files.Stats)
+		require.True(t, modTime().After(yesterday))
+	})
+}
+
+func TestUnlinkGenerated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	generated := []byte(generatedHeader + "package hello\n")
+	handwritten := []byte("package hello\n")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "generated.go"), generated, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "generated_object.go"), generated, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "generated_custom.go"), handwritten, 0644))
+
+	files := &Files{}
+	files.UnlinkGenerated(filepath.Join(dir, "generated.go"))
+
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "generated_custom.go")}, names)
+	require.Len(t, files.unlinked, 2)
+}
+
+func TestRenderErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gqlgen")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "hello.go")
+
+	t.Run("parse errors have the template line", func(t *testing.T) {
+		err := Render(Options{PackageName: "hello", Filename: filename, Template: "{{ range . }}\n{{ end }}\n{{ end }}"})
+		require.EqualError(t, err, "templates: failed to parse the template for "+filename+": template: template.gotpl:3: unexpected {{end}}")
+	})
+
+	t.Run("execution errors have the file being rendered and the template position", func(t *testing.T) {
+		err := Render(Options{PackageName: "hello", Filename: filename, Template: "\n{{ .Missing.Name }}", Data: struct{}{}})
+		require.EqualError(t, err, "templates: failed to render "+filename+`: template: template.gotpl:2:11: executing "template.gotpl" at <.Missing.Name>: can't evaluate field Missing in type struct {}`)
+
+		// a failed render doesn't stop the next one
+		require.NoError(t, Render(Options{PackageName: "hello", Filename: filename, Template: helloTemplate, Data: map[string]string{"Name": "world"}}))
+	})
+
+	t.Run("source that can't be formatted is kept in a .broken file", func(t *testing.T) {
+		err := Render(Options{PackageName: "hello", Filename: filename, Template: "func Broken() {"})
+		require.NoError(t, err)
+
+		broken, err := ioutil.ReadFile(filename + ".broken")
+		require.NoError(t, err)
+		require.Contains(t, string(broken), "func Broken() {")
+
+		require.NoError(t, Render(Options{PackageName: "hello", Filename: filename, Template: helloTemplate, Data: map[string]string{"Name": "world"}}))
+		_, err = os.Stat(filename + ".broken")
+		require.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,9 @@
+package builderrors
+
+type User struct {
+	Name     string
+	UserID   string
+	User_ID  string
+	OwnerID  string
+	Owner_ID string
+}
@@ -0,0 +1,6 @@
+package fieldmatching
+
+type User struct {
+	UserId  string
+	User_ID string
+}
@@ -0,0 +1,7 @@
+package forceresolver
+
+type User struct {
+	Name       string
+	OrderCount int
+	Email      string
+}
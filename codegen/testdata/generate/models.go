@@ -0,0 +1,52 @@
+package generate
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Node interface {
+	IsNode()
+}
+
+type Result interface {
+	IsResult()
+}
+
+type User struct {
+	ID   string
+	Name string
+	Role Role
+}
+
+func (User) IsNode()   {}
+func (User) IsResult() {}
+
+type Post struct {
+	ID    string
+	Title string
+}
+
+func (Post) IsNode()   {}
+func (Post) IsResult() {}
+
+type Filter struct {
+	Text *string
+	Role *Role
+}
+
+type Role string
+
+func (e *Role) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+	*e = Role(str)
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(e)))
+}
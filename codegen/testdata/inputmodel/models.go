@@ -0,0 +1,29 @@
+package inputmodel
+
+import "io"
+
+// Money is how money is returned, in cents
+type Money int
+
+func (m *Money) UnmarshalGQL(v interface{}) error { return nil }
+func (m Money) MarshalGQL(w io.Writer)            {}
+
+// MoneyInput is how money can be given, as an amount with a currency. It can't be marshaled.
+type MoneyInput struct {
+	Amount   string
+	Currency string
+}
+
+func (m *MoneyInput) UnmarshalGQL(v interface{}) error { return nil }
+
+type Account struct {
+	Balance Money
+}
+
+type BadAccount struct {
+	Balance MoneyInput
+}
+
+type Filter struct {
+	Above *MoneyInput
+}
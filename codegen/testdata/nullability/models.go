@@ -0,0 +1,13 @@
+package nullability
+
+type User struct {
+	Name  string
+	Email *string
+	Tags  []*string
+}
+
+type UserInput struct {
+	Name  *string
+	Email string
+	Tags  []string
+}
@@ -0,0 +1,8 @@
+package testserver
+
+type BoundEnum int
+
+const (
+	BoundEnumFirst BoundEnum = iota + 1
+	BoundEnumSecond
+)
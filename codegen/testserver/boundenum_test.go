@@ -0,0 +1,63 @@
+package testserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/handler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundEnum(t *testing.T) {
+	resolvers := &Stub{}
+
+	srv := httptest.NewServer(handler.GraphQL(NewExecutableSchema(Config{Resolvers: resolvers})))
+	c := client.New(srv.URL)
+
+	resolvers.QueryResolver.BoundEnum = func(ctx context.Context, arg BoundEnum) (BoundEnum, error) {
+		return arg, nil
+	}
+	resolvers.QueryResolver.BoundEnums = func(ctx context.Context, arg []BoundEnum) ([]BoundEnum, error) {
+		return append(arg, BoundEnumFirst), nil
+	}
+	resolvers.QueryResolver.NullableBoundEnum = func(ctx context.Context, arg *BoundEnum) (*BoundEnum, error) {
+		if arg == nil {
+			invalid := BoundEnum(99)
+			return &invalid, nil
+		}
+		return arg, nil
+	}
+
+	t.Run("values map to the bound go constants", func(t *testing.T) {
+		var resp struct{ BoundEnum string }
+		c.MustPost(`query { boundEnum(arg: SECOND) }`, &resp)
+		require.Equal(t, "SECOND", resp.BoundEnum)
+	})
+
+	t.Run("lists of bound enums", func(t *testing.T) {
+		var resp struct{ BoundEnums []string }
+		c.MustPost(`query { boundEnums(arg: [SECOND]) }`, &resp)
+		require.Equal(t, []string{"SECOND", "FIRST"}, resp.BoundEnums)
+	})
+
+	t.Run("nullable bound enums", func(t *testing.T) {
+		var resp struct{ NullableBoundEnum *string }
+		c.MustPost(`query { nullableBoundEnum(arg: FIRST) }`, &resp)
+		require.Equal(t, "FIRST", *resp.NullableBoundEnum)
+	})
+
+	t.Run("unknown go values are an error", func(t *testing.T) {
+		var resp struct{ NullableBoundEnum *string }
+		err := c.Post(`query { nullableBoundEnum }`, &resp)
+		require.EqualError(t, err, `[{"message":"99 is not a valid BoundEnum","path":["nullableBoundEnum"]}]`)
+		require.Nil(t, resp.NullableBoundEnum)
+	})
+
+	t.Run("unknown graphql values are an error", func(t *testing.T) {
+		var resp struct{ BoundEnum string }
+		err := c.Post(`query($arg: BoundEnum!) { boundEnum(arg: $arg) }`, &resp, client.Var("arg", "THIRD"))
+		require.Error(t, err)
+	})
+}
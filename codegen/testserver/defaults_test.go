@@ -0,0 +1,111 @@
+package testserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/handler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputDefaults(t *testing.T) {
+	resolvers := &Stub{}
+
+	srv := httptest.NewServer(handler.GraphQL(NewExecutableSchema(Config{Resolvers: resolvers})))
+	c := client.New(srv.URL)
+
+	var got InputWithDefaults
+	resolvers.QueryResolver.InputDefaults = func(ctx context.Context, input InputWithDefaults) (bool, error) {
+		got = input
+		return true, nil
+	}
+
+	t.Run("absent fields use their defaults", func(t *testing.T) {
+		var resp struct{ InputDefaults bool }
+		c.MustPost(`query { inputDefaults(input: {offset: 10}) }`, &resp)
+
+		require.Equal(t, 25, got.Limit)
+		require.Equal(t, 10, got.Offset)
+		require.Equal(t, "anon", *got.Name)
+		require.Equal(t, DefaultsStatusActive, got.Status)
+		require.Equal(t, []DefaultsStatus{DefaultsStatusActive, DefaultsStatusInactive}, got.Statuses)
+		require.Equal(t, &InnerDefaults{Value: 5, Tags: []string{"a", "b"}}, got.Inner)
+	})
+
+	t.Run("zero value defaults are applied", func(t *testing.T) {
+		var resp struct{ InputDefaults bool }
+		c.MustPost(`query { inputDefaults(input: {limit: 5}) }`, &resp)
+
+		require.Equal(t, 5, got.Limit)
+		require.Equal(t, 0, got.Offset)
+		require.NotNil(t, got.Enabled, "a nullable default of false should still be set")
+		require.False(t, *got.Enabled)
+	})
+
+	t.Run("explicit nulls are not replaced by defaults", func(t *testing.T) {
+		var resp struct{ InputDefaults bool }
+		c.MustPost(`query { inputDefaults(input: {name: null, enabled: null, statuses: null, inner: null}) }`, &resp)
+
+		require.Nil(t, got.Name)
+		require.Nil(t, got.Enabled)
+		require.Empty(t, got.Statuses)
+		require.Nil(t, got.Inner)
+	})
+
+	t.Run("defaults apply to inputs passed as variables", func(t *testing.T) {
+		var resp struct{ InputDefaults bool }
+		c.MustPost(`query($input: InputWithDefaults!) { inputDefaults(input: $input) }`, &resp,
+			client.Var("input", map[string]interface{}{"limit": 1, "offset": 2, "status": "INACTIVE", "name": "bob"}))
+
+		require.Equal(t, 1, got.Limit)
+		require.Equal(t, "bob", *got.Name)
+		require.Equal(t, []DefaultsStatus{DefaultsStatusActive, DefaultsStatusInactive}, got.Statuses)
+		require.Equal(t, &InnerDefaults{Value: 5, Tags: []string{"a", "b"}}, got.Inner)
+	})
+}
+
+func TestArgDefaults(t *testing.T) {
+	resolvers := &Stub{}
+
+	srv := httptest.NewServer(handler.GraphQL(NewExecutableSchema(Config{Resolvers: resolvers})))
+	c := client.New(srv.URL)
+
+	var gotInput *InputWithDefaults
+	var gotStatuses []DefaultsStatus
+	resolvers.QueryResolver.ArgDefaults = func(ctx context.Context, input *InputWithDefaults, statuses []DefaultsStatus) (bool, error) {
+		gotInput = input
+		gotStatuses = statuses
+		return true, nil
+	}
+
+	t.Run("omitted args use their literal defaults", func(t *testing.T) {
+		var resp struct{ ArgDefaults bool }
+		c.MustPost(`query { argDefaults }`, &resp)
+
+		require.Equal(t, 3, gotInput.Limit)
+		require.Nil(t, gotInput.Name)
+		require.Equal(t, []DefaultsStatus{DefaultsStatusInactive}, gotInput.Statuses)
+		require.Equal(t, &InnerDefaults{Value: 1, Tags: []string{"x"}}, gotInput.Inner)
+		require.Equal(t, []DefaultsStatus{DefaultsStatusInactive, DefaultsStatusActive}, gotStatuses)
+	})
+
+	t.Run("input field defaults apply within arg defaults", func(t *testing.T) {
+		var resp struct{ ArgDefaults bool }
+		c.MustPost(`query { argDefaults }`, &resp)
+
+		require.Equal(t, 0, gotInput.Offset)
+		require.Equal(t, DefaultsStatusActive, gotInput.Status)
+	})
+
+	t.Run("provided args replace the defaults", func(t *testing.T) {
+		var resp struct{ ArgDefaults bool }
+		c.MustPost(`query { argDefaults(input: {offset: 4}, statuses: [ACTIVE]) }`, &resp)
+
+		require.Equal(t, 25, gotInput.Limit)
+		require.Equal(t, 4, gotInput.Offset)
+		require.Equal(t, "anon", *gotInput.Name)
+		require.Equal(t, []DefaultsStatus{DefaultsStatusActive}, gotStatuses)
+	})
+}
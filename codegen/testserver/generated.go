@@ -127,9 +127,10 @@ type ComplexityRoot struct {
 	}
 
 	ModelMethods struct {
-		NoContext     func(childComplexity int) int
-		ResolverField func(childComplexity int) int
-		WithContext   func(childComplexity int) int
+		NoContext           func(childComplexity int) int
+		ResolverField       func(childComplexity int) int
+		WithContext         func(childComplexity int) int
+		WithContextAndError func(childComplexity int, fail bool) int
 	}
 
 	OuterObject struct {
@@ -149,7 +150,10 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
+		ArgDefaults            func(childComplexity int, input *InputWithDefaults, statuses []DefaultsStatus) int
 		Autobind               func(childComplexity int) int
+		BoundEnum              func(childComplexity int, arg BoundEnum) int
+		BoundEnums             func(childComplexity int, arg []BoundEnum) int
 		Collision              func(childComplexity int) int
 		DefaultScalar          func(childComplexity int, arg string) int
 		DeprecatedField        func(childComplexity int) int
@@ -160,6 +164,8 @@ type ComplexityRoot struct {
 		DirectiveNullableArg   func(childComplexity int, arg *int, arg2 *int) int
 		ErrorBubble            func(childComplexity int) int
 		Fallback               func(childComplexity int, arg FallbackToStringEncoding) int
+		InputDefaults          func(childComplexity int, input InputWithDefaults) int
+		InputOrder             func(childComplexity int, input InputOrder) int
 		InputSlice             func(childComplexity int, arg []string) int
 		InvalidIdentifier      func(childComplexity int) int
 		MapInput               func(childComplexity int, input map[string]interface{}) int
@@ -168,6 +174,7 @@ type ComplexityRoot struct {
 		NestedInputs           func(childComplexity int, input [][]*OuterInput) int
 		NestedOutputs          func(childComplexity int) int
 		NullableArg            func(childComplexity int, arg *int) int
+		NullableBoundEnum      func(childComplexity int, arg *BoundEnum) int
 		OptionalUnion          func(childComplexity int) int
 		Overlapping            func(childComplexity int) int
 		Panics                 func(childComplexity int) int
@@ -265,8 +272,15 @@ type QueryResolver interface {
 	InputSlice(ctx context.Context, arg []string) (bool, error)
 	ShapeUnion(ctx context.Context) (ShapeUnion, error)
 	Autobind(ctx context.Context) (*Autobind, error)
+	// Deprecated: test deprecated directive
 	DeprecatedField(ctx context.Context) (string, error)
+	BoundEnum(ctx context.Context, arg BoundEnum) (BoundEnum, error)
+	BoundEnums(ctx context.Context, arg []BoundEnum) ([]BoundEnum, error)
+	NullableBoundEnum(ctx context.Context, arg *BoundEnum) (*BoundEnum, error)
 	Overlapping(ctx context.Context) (*OverlappingFields, error)
+	InputDefaults(ctx context.Context, input InputWithDefaults) (bool, error)
+	ArgDefaults(ctx context.Context, input *InputWithDefaults, statuses []DefaultsStatus) (bool, error)
+	InputOrder(ctx context.Context, input InputOrder) (bool, error)
 	MapStringInterface(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error)
 	Panics(ctx context.Context) (*Panics, error)
 	DefaultScalar(ctx context.Context, arg string) (string, error)
@@ -495,6 +509,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ModelMethods.WithContext(childComplexity), true
 
+	case "ModelMethods.WithContextAndError":
+		if e.complexity.ModelMethods.WithContextAndError == nil {
+			break
+		}
+
+		args, err := ec.field_ModelMethods_withContextAndError_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.ModelMethods.WithContextAndError(childComplexity, args["fail"].(bool)), true
+
 	case "OuterObject.Inner":
 		if e.complexity.OuterObject.Inner == nil {
 			break
@@ -554,6 +580,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Panics.FieldScalarMarshal(childComplexity), true
 
+	case "Query.ArgDefaults":
+		if e.complexity.Query.ArgDefaults == nil {
+			break
+		}
+
+		args, err := ec.field_Query_argDefaults_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ArgDefaults(childComplexity, args["input"].(*InputWithDefaults), args["statuses"].([]DefaultsStatus)), true
+
 	case "Query.Autobind":
 		if e.complexity.Query.Autobind == nil {
 			break
@@ -561,6 +599,30 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Autobind(childComplexity), true
 
+	case "Query.BoundEnum":
+		if e.complexity.Query.BoundEnum == nil {
+			break
+		}
+
+		args, err := ec.field_Query_boundEnum_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.BoundEnum(childComplexity, args["arg"].(BoundEnum)), true
+
+	case "Query.BoundEnums":
+		if e.complexity.Query.BoundEnums == nil {
+			break
+		}
+
+		args, err := ec.field_Query_boundEnums_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.BoundEnums(childComplexity, args["arg"].([]BoundEnum)), true
+
 	case "Query.Collision":
 		if e.complexity.Query.Collision == nil {
 			break
@@ -666,6 +728,30 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Fallback(childComplexity, args["arg"].(FallbackToStringEncoding)), true
 
+	case "Query.InputDefaults":
+		if e.complexity.Query.InputDefaults == nil {
+			break
+		}
+
+		args, err := ec.field_Query_inputDefaults_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.InputDefaults(childComplexity, args["input"].(InputWithDefaults)), true
+
+	case "Query.InputOrder":
+		if e.complexity.Query.InputOrder == nil {
+			break
+		}
+
+		args, err := ec.field_Query_inputOrder_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.InputOrder(childComplexity, args["input"].(InputOrder)), true
+
 	case "Query.InputSlice":
 		if e.complexity.Query.InputSlice == nil {
 			break
@@ -747,6 +833,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.NullableArg(childComplexity, args["arg"].(*int)), true
 
+	case "Query.NullableBoundEnum":
+		if e.complexity.Query.NullableBoundEnum == nil {
+			break
+		}
+
+		args, err := ec.field_Query_nullableBoundEnum_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.NullableBoundEnum(childComplexity, args["arg"].(*BoundEnum)), true
+
 	case "Query.OptionalUnion":
 		if e.complexity.Query.OptionalUnion == nil {
 			break
@@ -1122,6 +1220,17 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 }
 
 var parsedSchema = gqlparser.MustLoadSchema(
+	&ast.Source{Name: "boundenum.graphql", Input: `extend type Query {
+    boundEnum(arg: BoundEnum!): BoundEnum!
+    boundEnums(arg: [BoundEnum!]): [BoundEnum!]
+    nullableBoundEnum(arg: BoundEnum): BoundEnum
+}
+
+enum BoundEnum {
+    FIRST
+    SECOND
+}
+`},
 	&ast.Source{Name: "builtinscalar.graphql", Input: `
 """
 Since gqlgen defines default implementation for a Map scalar, this tests that the builtin is _not_
@@ -1142,6 +1251,44 @@ type OverlappingFields {
   newFoo: Int!
   new_foo: Int!
 }
+`},
+	&ast.Source{Name: "defaults.graphql", Input: `extend type Query {
+    inputDefaults(input: InputWithDefaults!): Boolean!
+    argDefaults(
+        input: InputWithDefaults = {limit: 3, name: null, statuses: [INACTIVE], inner: {value: 1, tags: ["x"]}}
+        statuses: [DefaultsStatus!]! = [INACTIVE, ACTIVE]
+    ): Boolean!
+}
+
+enum DefaultsStatus {
+    ACTIVE
+    INACTIVE
+}
+
+input InputWithDefaults {
+    limit: Int! = 25
+    offset: Int! = 0
+    enabled: Boolean = false
+    name: String = "anon"
+    status: DefaultsStatus! = ACTIVE
+    statuses: [DefaultsStatus!] = [ACTIVE, INACTIVE]
+    inner: InnerDefaults = {value: 5, tags: ["a", "b"]}
+}
+
+input InnerDefaults {
+    value: Int!
+    tags: [String!]
+}
+`},
+	&ast.Source{Name: "inputorder.graphql", Input: `extend type Query {
+    inputOrder(input: InputOrder!): Boolean!
+}
+
+input InputOrder {
+    zeta: String! @length(min: 3)
+    alpha: String! @length(min: 0, max: 1)
+    mid: String
+}
 `},
 	&ast.Source{Name: "maps.graphql", Input: `extend type Query {
     mapStringInterface(in: MapStringInterfaceInput): MapStringInterfaceType
@@ -1237,6 +1384,7 @@ type ModelMethods {
     resolverField: Boolean!
     noContext: Boolean!
     withContext: Boolean!
+    withContextAndError(fail: Boolean!): Boolean
 }
 
 type InvalidIdentifier {
@@ -1485,6 +1633,20 @@ func (ec *executionContext) dir_range_args(ctx context.Context, rawArgs map[stri
 	return args, nil
 }
 
+func (ec *executionContext) field_ModelMethods_withContextAndError_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["fail"]; ok {
+		arg0, err = ec.unmarshalNBoolean2bool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["fail"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Panics_argUnmarshal_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1527,6 +1689,56 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_argDefaults_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *InputWithDefaults
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalOInputWithDefaults2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInputWithDefaults(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	var arg1 []DefaultsStatus
+	if tmp, ok := rawArgs["statuses"]; ok {
+		arg1, err = ec.unmarshalNDefaultsStatus2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["statuses"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_boundEnum_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 BoundEnum
+	if tmp, ok := rawArgs["arg"]; ok {
+		arg0, err = ec.unmarshalNBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["arg"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_boundEnums_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 []BoundEnum
+	if tmp, ok := rawArgs["arg"]; ok {
+		arg0, err = ec.unmarshalOBoundEnum2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["arg"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_defaultScalar_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1682,6 +1894,34 @@ func (ec *executionContext) field_Query_fallback_args(ctx context.Context, rawAr
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_inputDefaults_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 InputWithDefaults
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalNInputWithDefaults2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInputWithDefaults(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_inputOrder_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 InputOrder
+	if tmp, ok := rawArgs["input"]; ok {
+		arg0, err = ec.unmarshalNInputOrder2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInputOrder(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_inputSlice_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1752,6 +1992,20 @@ func (ec *executionContext) field_Query_nullableArg_args(ctx context.Context, ra
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_nullableBoundEnum_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *BoundEnum
+	if tmp, ok := rawArgs["arg"]; ok {
+		arg0, err = ec.unmarshalOBoundEnum2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["arg"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_recursive_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -2787,6 +3041,37 @@ func (ec *executionContext) _ModelMethods_withContext(ctx context.Context, field
 	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _ModelMethods_withContextAndError(ctx context.Context, field graphql.CollectedField, obj *ModelMethods) graphql.Marshaler {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
+	rctx := &graphql.ResolverContext{
+		Object:   "ModelMethods",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_ModelMethods_withContextAndError_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, obj, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.WithContextAndError(ctx, args["fail"].(bool))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _OuterObject_inner(ctx context.Context, field graphql.CollectedField, obj *OuterObject) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
@@ -3640,7 +3925,7 @@ func (ec *executionContext) _Query_deprecatedField(ctx context.Context, field gr
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_overlapping(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _Query_boundEnum(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
 	rctx := &graphql.ResolverContext{
@@ -3650,21 +3935,31 @@ func (ec *executionContext) _Query_overlapping(ctx context.Context, field graphq
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_boundEnum_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Overlapping(rctx)
+		return ec.resolvers.Query().BoundEnum(rctx, args["arg"].(BoundEnum))
 	})
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*OverlappingFields)
+	res := resTmp.(BoundEnum)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOOverlappingFields2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐOverlappingFields(ctx, field.Selections, res)
+	return ec.marshalNBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_mapStringInterface(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _Query_boundEnums(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
 	rctx := &graphql.ResolverContext{
@@ -3675,7 +3970,7 @@ func (ec *executionContext) _Query_mapStringInterface(ctx context.Context, field
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_mapStringInterface_args(ctx, rawArgs)
+	args, err := ec.field_Query_boundEnums_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -3684,18 +3979,18 @@ func (ec *executionContext) _Query_mapStringInterface(ctx context.Context, field
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().MapStringInterface(rctx, args["in"].(map[string]interface{}))
+		return ec.resolvers.Query().BoundEnums(rctx, args["arg"].([]BoundEnum))
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.([]BoundEnum)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOMapStringInterfaceType2map(ctx, field.Selections, res)
+	return ec.marshalOBoundEnum2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_panics(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _Query_nullableBoundEnum(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
 	rctx := &graphql.ResolverContext{
@@ -3705,21 +4000,28 @@ func (ec *executionContext) _Query_panics(ctx context.Context, field graphql.Col
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_nullableBoundEnum_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Panics(rctx)
+		return ec.resolvers.Query().NullableBoundEnum(rctx, args["arg"].(*BoundEnum))
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*Panics)
+	res := resTmp.(*BoundEnum)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOPanics2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐPanics(ctx, field.Selections, res)
+	return ec.marshalOBoundEnum2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_defaultScalar(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _Query_overlapping(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
 	rctx := &graphql.ResolverContext{
@@ -3729,31 +4031,21 @@ func (ec *executionContext) _Query_defaultScalar(ctx context.Context, field grap
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_defaultScalar_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DefaultScalar(rctx, args["arg"].(string))
+		return ec.resolvers.Query().Overlapping(rctx)
 	})
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*OverlappingFields)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNDefaultScalarImplementation2string(ctx, field.Selections, res)
+	return ec.marshalOOverlappingFields2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐOverlappingFields(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_slices(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _Query_inputDefaults(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
 	rctx := &graphql.ResolverContext{
@@ -3763,21 +4055,31 @@ func (ec *executionContext) _Query_slices(ctx context.Context, field graphql.Col
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_inputDefaults_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Slices(rctx)
+		return ec.resolvers.Query().InputDefaults(rctx, args["input"].(InputWithDefaults))
 	})
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*Slices)
+	res := resTmp.(bool)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOSlices2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐSlices(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_scalarSlice(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _Query_argDefaults(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
 	rctx := &graphql.ResolverContext{
@@ -3787,10 +4089,17 @@ func (ec *executionContext) _Query_scalarSlice(ctx context.Context, field graphq
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_argDefaults_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ScalarSlice(rctx)
+		return ec.resolvers.Query().ArgDefaults(rctx, args["input"].(*InputWithDefaults), args["statuses"].([]DefaultsStatus))
 	})
 	if resTmp == nil {
 		if !ec.HasError(rctx) {
@@ -3798,13 +4107,13 @@ func (ec *executionContext) _Query_scalarSlice(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]byte)
+	res := resTmp.(bool)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNBytes2ᚕbyte(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_fallback(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _Query_inputOrder(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
 	rctx := &graphql.ResolverContext{
@@ -3815,7 +4124,7 @@ func (ec *executionContext) _Query_fallback(ctx context.Context, field graphql.C
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_fallback_args(ctx, rawArgs)
+	args, err := ec.field_Query_inputOrder_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -3824,7 +4133,181 @@ func (ec *executionContext) _Query_fallback(ctx context.Context, field graphql.C
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Fallback(rctx, args["arg"].(FallbackToStringEncoding))
+		return ec.resolvers.Query().InputOrder(rctx, args["input"].(InputOrder))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_mapStringInterface(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
+	rctx := &graphql.ResolverContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_mapStringInterface_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().MapStringInterface(rctx, args["in"].(map[string]interface{}))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(map[string]interface{})
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOMapStringInterfaceType2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_panics(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
+	rctx := &graphql.ResolverContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Panics(rctx)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*Panics)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOPanics2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐPanics(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_defaultScalar(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
+	rctx := &graphql.ResolverContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_defaultScalar_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().DefaultScalar(rctx, args["arg"].(string))
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNDefaultScalarImplementation2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_slices(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
+	rctx := &graphql.ResolverContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Slices(rctx)
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*Slices)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOSlices2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐSlices(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_scalarSlice(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
+	rctx := &graphql.ResolverContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().ScalarSlice(rctx)
+	})
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]byte)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNBytes2ᚕbyte(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_fallback(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() { ec.Tracer.EndFieldExecution(ctx) }()
+	rctx := &graphql.ResolverContext{
+		Object:   "Query",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_fallback_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp := ec.FieldMiddleware(ctx, nil, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Fallback(rctx, args["arg"].(FallbackToStringEncoding))
 	})
 	if resTmp == nil {
 		if !ec.HasError(rctx) {
@@ -5337,11 +5820,45 @@ func (ec *executionContext) _iIt_id(ctx context.Context, field graphql.Collected
 
 // region    **************************** input.gotpl *****************************
 
+func (ec *executionContext) unmarshalInputInnerDefaults(ctx context.Context, v interface{}) (InnerDefaults, error) {
+	var it InnerDefaults
+	var asMap = v.(map[string]interface{})
+
+	fieldsInOrder := [...]string{"value", "tags"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "value":
+			var err error
+			it.Value, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "tags":
+			var err error
+			it.Tags, err = ec.unmarshalOString2ᚕstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputInnerDirectives(ctx context.Context, v interface{}) (InnerDirectives, error) {
 	var it InnerDirectives
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"message"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
 		case "message":
 			var err error
@@ -5370,7 +5887,12 @@ func (ec *executionContext) unmarshalInputInnerInput(ctx context.Context, v inte
 	var it InnerInput
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"id"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
 		case "id":
 			var err error
@@ -5388,7 +5910,12 @@ func (ec *executionContext) unmarshalInputInputDirectives(ctx context.Context, v
 	var it InputDirectives
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"text", "inner", "innerNullable", "thirdParty"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
 		case "text":
 			var err error
@@ -5446,33 +5973,55 @@ func (ec *executionContext) unmarshalInputInputDirectives(ctx context.Context, v
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputOuterInput(ctx context.Context, v interface{}) (OuterInput, error) {
-	var it OuterInput
+func (ec *executionContext) unmarshalInputInputOrder(ctx context.Context, v interface{}) (InputOrder, error) {
+	var it InputOrder
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"zeta", "alpha", "mid"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
-		case "inner":
+		case "zeta":
 			var err error
-			it.Inner, err = ec.unmarshalNInnerInput2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInnerInput(ctx, v)
+			getField0 := func(ctx context.Context) (interface{}, error) { return ec.unmarshalNString2string(ctx, v) }
+			getField1 := func(ctx context.Context) (res interface{}, err error) {
+				n := getField0
+				return ec.directives.Length(ctx, it, n, 3, nil)
+			}
+
+			tmp, err := getField1(ctx)
 			if err != nil {
 				return it, err
 			}
-		}
-	}
-
-	return it, nil
-}
-
-func (ec *executionContext) unmarshalInputRecursiveInputSlice(ctx context.Context, v interface{}) (RecursiveInputSlice, error) {
-	var it RecursiveInputSlice
-	var asMap = v.(map[string]interface{})
+			if data, ok := tmp.(string); ok {
+				it.Zeta = data
+			} else {
+				return it, fmt.Errorf(`unexpected type %T from directive, should be string`, tmp)
+			}
+		case "alpha":
+			var err error
+			getField0 := func(ctx context.Context) (interface{}, error) { return ec.unmarshalNString2string(ctx, v) }
+			getField1 := func(ctx context.Context) (res interface{}, err error) {
+				max := 1
+				n := getField0
+				return ec.directives.Length(ctx, it, n, 0, &max)
+			}
 
-	for k, v := range asMap {
-		switch k {
-		case "self":
+			tmp, err := getField1(ctx)
+			if err != nil {
+				return it, err
+			}
+			if data, ok := tmp.(string); ok {
+				it.Alpha = data
+			} else {
+				return it, fmt.Errorf(`unexpected type %T from directive, should be string`, tmp)
+			}
+		case "mid":
 			var err error
-			it.Self, err = ec.unmarshalORecursiveInputSlice2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐRecursiveInputSlice(ctx, v)
+			it.Mid, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -5482,15 +6031,147 @@ func (ec *executionContext) unmarshalInputRecursiveInputSlice(ctx context.Contex
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputValidInput(ctx context.Context, v interface{}) (ValidInput, error) {
-	var it ValidInput
+func (ec *executionContext) unmarshalInputInputWithDefaults(ctx context.Context, v interface{}) (InputWithDefaults, error) {
+	var it InputWithDefaults
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	if _, present := asMap["limit"]; !present {
+		asMap["limit"] = 25
+	}
+	if _, present := asMap["offset"]; !present {
+		asMap["offset"] = 0
+	}
+	if _, present := asMap["enabled"]; !present {
+		asMap["enabled"] = false
+	}
+	if _, present := asMap["name"]; !present {
+		asMap["name"] = "anon"
+	}
+	if _, present := asMap["status"]; !present {
+		asMap["status"] = "ACTIVE"
+	}
+	if _, present := asMap["statuses"]; !present {
+		asMap["statuses"] = []interface{}{"ACTIVE", "INACTIVE"}
+	}
+	if _, present := asMap["inner"]; !present {
+		asMap["inner"] = map[string]interface{}{"tags": []interface{}{"a", "b"}, "value": 5}
+	}
+
+	fieldsInOrder := [...]string{"limit", "offset", "enabled", "name", "status", "statuses", "inner"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
-		case "break":
+		case "limit":
 			var err error
-			it.Break, err = ec.unmarshalNString2string(ctx, v)
+			it.Limit, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "offset":
+			var err error
+			it.Offset, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "enabled":
+			var err error
+			it.Enabled, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "name":
+			var err error
+			it.Name, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "status":
+			var err error
+			it.Status, err = ec.unmarshalNDefaultsStatus2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "statuses":
+			var err error
+			it.Statuses, err = ec.unmarshalODefaultsStatus2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "inner":
+			var err error
+			it.Inner, err = ec.unmarshalOInnerDefaults2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInnerDefaults(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputOuterInput(ctx context.Context, v interface{}) (OuterInput, error) {
+	var it OuterInput
+	var asMap = v.(map[string]interface{})
+
+	fieldsInOrder := [...]string{"inner"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "inner":
+			var err error
+			it.Inner, err = ec.unmarshalNInnerInput2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInnerInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputRecursiveInputSlice(ctx context.Context, v interface{}) (RecursiveInputSlice, error) {
+	var it RecursiveInputSlice
+	var asMap = v.(map[string]interface{})
+
+	fieldsInOrder := [...]string{"self"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "self":
+			var err error
+			it.Self, err = ec.unmarshalORecursiveInputSlice2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐRecursiveInputSlice(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputValidInput(ctx context.Context, v interface{}) (ValidInput, error) {
+	var it ValidInput
+	var asMap = v.(map[string]interface{})
+
+	fieldsInOrder := [...]string{"break", "default", "func", "interface", "select", "case", "defer", "go", "map", "struct", "chan", "else", "goto", "package", "switch", "const", "fallthrough", "if", "range", "type", "continue", "for", "import", "return", "var", "_"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "break":
+			var err error
+			it.Break, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6182,6 +6863,17 @@ func (ec *executionContext) _ModelMethods(ctx context.Context, sel ast.Selection
 				}
 				return res
 			})
+		case "withContextAndError":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._ModelMethods_withContextAndError(ctx, field, obj)
+				return res
+			})
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -6601,6 +7293,42 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				}
 				return res
 			})
+		case "boundEnum":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_boundEnum(ctx, field)
+				if res == graphql.Null {
+					invalid = true
+				}
+				return res
+			})
+		case "boundEnums":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_boundEnums(ctx, field)
+				return res
+			})
+		case "nullableBoundEnum":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_nullableBoundEnum(ctx, field)
+				return res
+			})
 		case "overlapping":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
@@ -6612,6 +7340,48 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				res = ec._Query_overlapping(ctx, field)
 				return res
 			})
+		case "inputDefaults":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_inputDefaults(ctx, field)
+				if res == graphql.Null {
+					invalid = true
+				}
+				return res
+			})
+		case "argDefaults":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_argDefaults(ctx, field)
+				if res == graphql.Null {
+					invalid = true
+				}
+				return res
+			})
+		case "inputOrder":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_inputOrder(ctx, field)
+				if res == graphql.Null {
+					invalid = true
+				}
+				return res
+			})
 		case "mapStringInterface":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
@@ -7261,6 +8031,33 @@ func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.Se
 	return graphql.MarshalBoolean(v)
 }
 
+func (ec *executionContext) unmarshalNBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx context.Context, v interface{}) (BoundEnum, error) {
+	tmp, err := graphql.UnmarshalString(v)
+	if err != nil {
+		var res BoundEnum
+		return res, err
+	}
+	switch tmp {
+	case "FIRST":
+		return BoundEnumFirst, nil
+	case "SECOND":
+		return BoundEnumSecond, nil
+	}
+	var res BoundEnum
+	return res, fmt.Errorf("%s is not a valid BoundEnum", tmp)
+}
+
+func (ec *executionContext) marshalNBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx context.Context, sel ast.SelectionSet, v BoundEnum) graphql.Marshaler {
+	switch v {
+	case BoundEnumFirst:
+		return graphql.MarshalString("FIRST")
+	case BoundEnumSecond:
+		return graphql.MarshalString("SECOND")
+	}
+	ec.Errorf(ctx, "%v is not a valid BoundEnum", v)
+	return graphql.Null
+}
+
 func (ec *executionContext) unmarshalNBytes2ᚕbyte(ctx context.Context, v interface{}) ([]byte, error) {
 	return UnmarshalBytes(v)
 }
@@ -7277,6 +8074,72 @@ func (ec *executionContext) marshalNDefaultScalarImplementation2string(ctx conte
 	return graphql.MarshalString(v)
 }
 
+func (ec *executionContext) unmarshalNDefaultsStatus2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx context.Context, v interface{}) (DefaultsStatus, error) {
+	var res DefaultsStatus
+	return res, res.UnmarshalGQL(v)
+}
+
+func (ec *executionContext) marshalNDefaultsStatus2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx context.Context, sel ast.SelectionSet, v DefaultsStatus) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) unmarshalNDefaultsStatus2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx context.Context, v interface{}) ([]DefaultsStatus, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]DefaultsStatus, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNDefaultsStatus2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalNDefaultsStatus2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx context.Context, sel ast.SelectionSet, v []DefaultsStatus) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		rctx := &graphql.ResolverContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNDefaultsStatus2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
 func (ec *executionContext) unmarshalNFallbackToStringEncoding2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐFallbackToStringEncoding(ctx context.Context, v interface{}) (FallbackToStringEncoding, error) {
 	tmp, err := graphql.UnmarshalString(v)
 	return FallbackToStringEncoding(tmp), err
@@ -7318,6 +8181,14 @@ func (ec *executionContext) unmarshalNInputDirectives2githubᚗcomᚋ99designs
 	return ec.unmarshalInputInputDirectives(ctx, v)
 }
 
+func (ec *executionContext) unmarshalNInputOrder2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInputOrder(ctx context.Context, v interface{}) (InputOrder, error) {
+	return ec.unmarshalInputInputOrder(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNInputWithDefaults2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInputWithDefaults(ctx context.Context, v interface{}) (InputWithDefaults, error) {
+	return ec.unmarshalInputInputWithDefaults(ctx, v)
+}
+
 func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v interface{}) (int, error) {
 	return graphql.UnmarshalInt(v)
 }
@@ -7785,6 +8656,108 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return ec.marshalOBoolean2bool(ctx, sel, *v)
 }
 
+func (ec *executionContext) unmarshalOBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx context.Context, v interface{}) (BoundEnum, error) {
+	tmp, err := graphql.UnmarshalString(v)
+	if err != nil {
+		var res BoundEnum
+		return res, err
+	}
+	switch tmp {
+	case "FIRST":
+		return BoundEnumFirst, nil
+	case "SECOND":
+		return BoundEnumSecond, nil
+	}
+	var res BoundEnum
+	return res, fmt.Errorf("%s is not a valid BoundEnum", tmp)
+}
+
+func (ec *executionContext) marshalOBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx context.Context, sel ast.SelectionSet, v BoundEnum) graphql.Marshaler {
+	switch v {
+	case BoundEnumFirst:
+		return graphql.MarshalString("FIRST")
+	case BoundEnumSecond:
+		return graphql.MarshalString("SECOND")
+	}
+	ec.Errorf(ctx, "%v is not a valid BoundEnum", v)
+	return graphql.Null
+}
+
+func (ec *executionContext) unmarshalOBoundEnum2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx context.Context, v interface{}) ([]BoundEnum, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]BoundEnum, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOBoundEnum2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx context.Context, sel ast.SelectionSet, v []BoundEnum) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		rctx := &graphql.ResolverContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
+func (ec *executionContext) unmarshalOBoundEnum2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx context.Context, v interface{}) (*BoundEnum, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalOBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, v)
+	return &res, err
+}
+
+func (ec *executionContext) marshalOBoundEnum2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx context.Context, sel ast.SelectionSet, v *BoundEnum) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec.marshalOBoundEnum2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐBoundEnum(ctx, sel, *v)
+}
+
 func (ec *executionContext) unmarshalOChanges2map(ctx context.Context, v interface{}) (map[string]interface{}, error) {
 	if v == nil {
 		return nil, nil
@@ -7826,6 +8799,66 @@ func (ec *executionContext) marshalODefaultScalarImplementation2ᚖstring(ctx co
 	return ec.marshalODefaultScalarImplementation2string(ctx, sel, *v)
 }
 
+func (ec *executionContext) unmarshalODefaultsStatus2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx context.Context, v interface{}) ([]DefaultsStatus, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]DefaultsStatus, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNDefaultsStatus2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalODefaultsStatus2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx context.Context, sel ast.SelectionSet, v []DefaultsStatus) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		rctx := &graphql.ResolverContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNDefaultsStatus2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐDefaultsStatus(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
 func (ec *executionContext) marshalOError2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐError(ctx context.Context, sel ast.SelectionSet, v Error) graphql.Marshaler {
 	return ec._Error(ctx, sel, &v)
 }
@@ -7845,6 +8878,18 @@ func (ec *executionContext) marshalOFloat2float64(ctx context.Context, sel ast.S
 	return graphql.MarshalFloat(v)
 }
 
+func (ec *executionContext) unmarshalOInnerDefaults2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInnerDefaults(ctx context.Context, v interface{}) (InnerDefaults, error) {
+	return ec.unmarshalInputInnerDefaults(ctx, v)
+}
+
+func (ec *executionContext) unmarshalOInnerDefaults2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInnerDefaults(ctx context.Context, v interface{}) (*InnerDefaults, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalOInnerDefaults2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInnerDefaults(ctx, v)
+	return &res, err
+}
+
 func (ec *executionContext) unmarshalOInnerDirectives2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInnerDirectives(ctx context.Context, v interface{}) (InnerDirectives, error) {
 	return ec.unmarshalInputInnerDirectives(ctx, v)
 }
@@ -7869,6 +8914,18 @@ func (ec *executionContext) unmarshalOInputDirectives2ᚖgithubᚗcomᚋ99design
 	return &res, err
 }
 
+func (ec *executionContext) unmarshalOInputWithDefaults2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInputWithDefaults(ctx context.Context, v interface{}) (InputWithDefaults, error) {
+	return ec.unmarshalInputInputWithDefaults(ctx, v)
+}
+
+func (ec *executionContext) unmarshalOInputWithDefaults2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInputWithDefaults(ctx context.Context, v interface{}) (*InputWithDefaults, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalOInputWithDefaults2githubᚗcomᚋ99designsᚋgqlgenᚋcodegenᚋtestserverᚐInputWithDefaults(ctx, v)
+	return &res, err
+}
+
 func (ec *executionContext) unmarshalOInt2int(ctx context.Context, v interface{}) (int, error) {
 	return graphql.UnmarshalInt(v)
 }
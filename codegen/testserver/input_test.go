@@ -2,10 +2,12 @@ package testserver
 
 import (
 	"context"
+	"fmt"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/handler"
 	"github.com/stretchr/testify/require"
 )
@@ -32,3 +34,53 @@ func TestInput(t *testing.T) {
 		require.Nil(t, resp.DirectiveArg)
 	})
 }
+
+func TestInputFieldOrder(t *testing.T) {
+	resolvers := &Stub{}
+	resolvers.QueryResolver.InputOrder = func(ctx context.Context, input InputOrder) (bool, error) {
+		return true, nil
+	}
+
+	var seen []interface{}
+	srv := httptest.NewServer(handler.GraphQL(NewExecutableSchema(Config{
+		Resolvers: resolvers,
+		Directives: DirectiveRoot{
+			Length: func(ctx context.Context, obj interface{}, next graphql.Resolver, min int, max *int) (interface{}, error) {
+				res, err := next(ctx)
+				if err != nil {
+					return nil, err
+				}
+				seen = append(seen, res)
+
+				s := res.(string)
+				if len(s) < min {
+					return nil, fmt.Errorf("too short")
+				}
+				if max != nil && len(s) > *max {
+					return nil, fmt.Errorf("too long")
+				}
+				return res, nil
+			},
+		},
+	})))
+	c := client.New(srv.URL)
+
+	t.Run("fields are unmarshaled in schema order", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			seen = nil
+			var resp struct{ InputOrder bool }
+			c.MustPost(`query { inputOrder(input: {mid: "m", alpha: "a", zeta: "zzz"}) }`, &resp)
+
+			require.Equal(t, []interface{}{"zzz", "a"}, seen)
+		}
+	})
+
+	t.Run("the first invalid field in schema order is reported", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			var resp struct{ InputOrder bool }
+			err := c.Post(`query { inputOrder(input: {alpha: "aaa", zeta: "z"}) }`, &resp)
+
+			require.EqualError(t, err, `[{"message":"too short","path":["inputOrder"]}]`)
+		}
+	})
+}
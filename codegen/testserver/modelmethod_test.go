@@ -45,4 +45,17 @@ func TestModelMethods(t *testing.T) {
 		require.NoError(t, err)
 		require.True(t, resp.ModelMethods.WithContext)
 	})
+	t.Run("with context and error", func(t *testing.T) {
+		var resp struct {
+			ModelMethods struct {
+				WithContextAndError *bool
+			}
+		}
+		err := c.Post(`query { modelMethods{ withContextAndError(fail: false) } }`, &resp)
+		require.NoError(t, err)
+		require.True(t, *resp.ModelMethods.WithContextAndError)
+
+		err = c.Post(`query { modelMethods{ withContextAndError(fail: true) } }`, &resp)
+		require.EqualError(t, err, `[{"message":"failed at [modelMethods withContextAndError]","path":["modelMethods","withContextAndError"]}]`)
+	})
 }
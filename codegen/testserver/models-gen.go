@@ -37,6 +37,11 @@ type EmbeddedDefaultScalar struct {
 	Value *string `json:"value"`
 }
 
+type InnerDefaults struct {
+	Value int      `json:"value"`
+	Tags  []string `json:"tags"`
+}
+
 type InnerDirectives struct {
 	Message string `json:"message"`
 }
@@ -56,6 +61,22 @@ type InputDirectives struct {
 	ThirdParty    *ThirdParty      `json:"thirdParty"`
 }
 
+type InputOrder struct {
+	Zeta  string  `json:"zeta"`
+	Alpha string  `json:"alpha"`
+	Mid   *string `json:"mid"`
+}
+
+type InputWithDefaults struct {
+	Limit    int              `json:"limit"`
+	Offset   int              `json:"offset"`
+	Enabled  *bool            `json:"enabled"`
+	Name     *string          `json:"name"`
+	Status   DefaultsStatus   `json:"status"`
+	Statuses []DefaultsStatus `json:"statuses"`
+	Inner    *InnerDefaults   `json:"inner"`
+}
+
 // Since gqlgen defines default implementation for a Map scalar, this tests that the builtin is _not_
 // added to the TypeMap
 type Map struct {
@@ -137,6 +158,47 @@ type IIt struct {
 	ID string `json:"id"`
 }
 
+type DefaultsStatus string
+
+const (
+	DefaultsStatusActive   DefaultsStatus = "ACTIVE"
+	DefaultsStatusInactive DefaultsStatus = "INACTIVE"
+)
+
+var AllDefaultsStatus = []DefaultsStatus{
+	DefaultsStatusActive,
+	DefaultsStatusInactive,
+}
+
+func (e DefaultsStatus) IsValid() bool {
+	switch e {
+	case DefaultsStatusActive, DefaultsStatusInactive:
+		return true
+	}
+	return false
+}
+
+func (e DefaultsStatus) String() string {
+	return string(e)
+}
+
+func (e *DefaultsStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DefaultsStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DefaultsStatus", str)
+	}
+	return nil
+}
+
+func (e DefaultsStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type Status string
 
 const (
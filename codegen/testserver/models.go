@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+
+	"github.com/99designs/gqlgen/graphql"
 )
 
 type ForcedResolver struct {
@@ -21,6 +23,13 @@ func (m ModelMethods) WithContext(_ context.Context) bool {
 	return true
 }
 
+func (m *ModelMethods) WithContextAndError(ctx context.Context, fail bool) (bool, error) {
+	if fail {
+		return false, fmt.Errorf("failed at %s", graphql.GetResolverContext(ctx).Path())
+	}
+	return true, nil
+}
+
 type Error struct {
 	ID string
 }
@@ -125,9 +125,27 @@ func (r *queryResolver) Autobind(ctx context.Context) (*Autobind, error) {
 func (r *queryResolver) DeprecatedField(ctx context.Context) (string, error) {
 	panic("not implemented")
 }
+func (r *queryResolver) BoundEnum(ctx context.Context, arg BoundEnum) (BoundEnum, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) BoundEnums(ctx context.Context, arg []BoundEnum) ([]BoundEnum, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) NullableBoundEnum(ctx context.Context, arg *BoundEnum) (*BoundEnum, error) {
+	panic("not implemented")
+}
 func (r *queryResolver) Overlapping(ctx context.Context) (*OverlappingFields, error) {
 	panic("not implemented")
 }
+func (r *queryResolver) InputDefaults(ctx context.Context, input InputWithDefaults) (bool, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) ArgDefaults(ctx context.Context, input *InputWithDefaults, statuses []DefaultsStatus) (bool, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) InputOrder(ctx context.Context, input InputOrder) (bool, error) {
+	panic("not implemented")
+}
 func (r *queryResolver) MapStringInterface(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
 	panic("not implemented")
 }
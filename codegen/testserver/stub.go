@@ -45,7 +45,13 @@ type Stub struct {
 		ShapeUnion             func(ctx context.Context) (ShapeUnion, error)
 		Autobind               func(ctx context.Context) (*Autobind, error)
 		DeprecatedField        func(ctx context.Context) (string, error)
+		BoundEnum              func(ctx context.Context, arg BoundEnum) (BoundEnum, error)
+		BoundEnums             func(ctx context.Context, arg []BoundEnum) ([]BoundEnum, error)
+		NullableBoundEnum      func(ctx context.Context, arg *BoundEnum) (*BoundEnum, error)
 		Overlapping            func(ctx context.Context) (*OverlappingFields, error)
+		InputDefaults          func(ctx context.Context, input InputWithDefaults) (bool, error)
+		ArgDefaults            func(ctx context.Context, input *InputWithDefaults, statuses []DefaultsStatus) (bool, error)
+		InputOrder             func(ctx context.Context, input InputOrder) (bool, error)
 		MapStringInterface     func(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error)
 		Panics                 func(ctx context.Context) (*Panics, error)
 		DefaultScalar          func(ctx context.Context, arg string) (string, error)
@@ -178,9 +184,27 @@ func (r *stubQuery) Autobind(ctx context.Context) (*Autobind, error) {
 func (r *stubQuery) DeprecatedField(ctx context.Context) (string, error) {
 	return r.QueryResolver.DeprecatedField(ctx)
 }
+func (r *stubQuery) BoundEnum(ctx context.Context, arg BoundEnum) (BoundEnum, error) {
+	return r.QueryResolver.BoundEnum(ctx, arg)
+}
+func (r *stubQuery) BoundEnums(ctx context.Context, arg []BoundEnum) ([]BoundEnum, error) {
+	return r.QueryResolver.BoundEnums(ctx, arg)
+}
+func (r *stubQuery) NullableBoundEnum(ctx context.Context, arg *BoundEnum) (*BoundEnum, error) {
+	return r.QueryResolver.NullableBoundEnum(ctx, arg)
+}
 func (r *stubQuery) Overlapping(ctx context.Context) (*OverlappingFields, error) {
 	return r.QueryResolver.Overlapping(ctx)
 }
+func (r *stubQuery) InputDefaults(ctx context.Context, input InputWithDefaults) (bool, error) {
+	return r.QueryResolver.InputDefaults(ctx, input)
+}
+func (r *stubQuery) ArgDefaults(ctx context.Context, input *InputWithDefaults, statuses []DefaultsStatus) (bool, error) {
+	return r.QueryResolver.ArgDefaults(ctx, input, statuses)
+}
+func (r *stubQuery) InputOrder(ctx context.Context, input InputOrder) (bool, error) {
+	return r.QueryResolver.InputOrder(ctx, input)
+}
 func (r *stubQuery) MapStringInterface(ctx context.Context, in map[string]interface{}) (map[string]interface{}, error) {
 	return r.QueryResolver.MapStringInterface(ctx, in)
 }
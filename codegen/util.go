@@ -45,3 +45,11 @@ func equalFieldName(source, target string) bool {
 	target = strings.Replace(target, "_", "", -1)
 	return strings.EqualFold(source, target)
 }
+
+// tagName strips any options like omitempty from a struct tag value
+func tagName(val string) string {
+	if i := strings.Index(val, ","); i >= 0 {
+		return val[:i]
+	}
+	return val
+}
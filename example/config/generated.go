@@ -1467,7 +1467,12 @@ func (ec *executionContext) unmarshalInputNewTodo(ctx context.Context, v interfa
 	var it NewTodo
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"text", "userId"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
 		case "text":
 			var err error
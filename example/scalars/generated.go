@@ -1578,7 +1578,12 @@ func (ec *executionContext) unmarshalInputSearchArgs(ctx context.Context, v inte
 	var it model.SearchArgs
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"location", "createdAfter", "isBanned"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
 		case "location":
 			var err error
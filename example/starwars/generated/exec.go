@@ -2898,7 +2898,12 @@ func (ec *executionContext) unmarshalInputReviewInput(ctx context.Context, v int
 	var it models.Review
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"stars", "commentary", "time"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
 		case "stars":
 			var err error
@@ -1525,7 +1525,12 @@ func (ec *executionContext) unmarshalInputTodoInput(ctx context.Context, v inter
 	var it TodoInput
 	var asMap = v.(map[string]interface{})
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"text", "done"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
 		case "text":
 			var err error
@@ -14,6 +14,7 @@ type Data interface {
 
 type Node interface {
 	IsNode()
+	GetID() string
 }
 
 type Todo struct {
@@ -23,8 +24,9 @@ type Todo struct {
 	Verified bool   `json:"verified"`
 }
 
-func (Todo) IsNode() {}
-func (Todo) IsData() {}
+func (Todo) IsNode()            {}
+func (Todo) IsData()            {}
+func (this Todo) GetID() string { return this.ID }
 
 type TodoInput struct {
 	Text string `json:"text"`
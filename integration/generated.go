@@ -1610,7 +1610,12 @@ func (ec *executionContext) unmarshalInputDateFilter(ctx context.Context, v inte
 		asMap["op"] = "EQ"
 	}
 
-	for k, v := range asMap {
+	fieldsInOrder := [...]string{"value", "timezone", "op"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
 		switch k {
 		case "value":
 			var err error
@@ -0,0 +1,64 @@
+package code
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GoModProvides finds the go.mod for dir and reports whether importPath is in its module or in one of the modules it
+// requires. The standard library is always provided. gomod is empty when there is no go.mod.
+func GoModProvides(dir string, importPath string) (gomod string, provided bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			gomod = filepath.Join(dir, "go.mod")
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+
+	if !strings.Contains(strings.Split(importPath, "/")[0], ".") {
+		return gomod, true
+	}
+
+	f, err := os.Open(gomod)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	inRequire := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var modulePath string
+		switch {
+		case inRequire && fields[0] == ")":
+			inRequire = false
+		case inRequire:
+			modulePath = fields[0]
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inRequire = true
+		case (fields[0] == "module" || fields[0] == "require") && len(fields) > 1:
+			modulePath = strings.Trim(fields[1], `"`)
+		}
+
+		if modulePath != "" && (importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")) {
+			return gomod, true
+		}
+	}
+	return gomod, false
+}
@@ -0,0 +1,31 @@
+package code
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoModProvides(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	root := filepath.Join(wd, "..", "..")
+
+	gomod, provided := GoModProvides(wd, "github.com/99designs/gqlgen/graphql")
+	require.Equal(t, filepath.Join(root, "go.mod"), filepath.Clean(gomod))
+	require.True(t, provided)
+
+	_, provided = GoModProvides(wd, "github.com/vektah/gqlparser/ast")
+	require.True(t, provided)
+
+	_, provided = GoModProvides(wd, "fmt")
+	require.True(t, provided)
+
+	_, provided = GoModProvides(wd, "github.com/acme/api/model")
+	require.False(t, provided)
+
+	_, provided = GoModProvides(wd, "github.com/99designs/gqlgenerator")
+	require.False(t, provided)
+}
@@ -9,6 +9,7 @@ import (
 	"go/printer"
 	"go/token"
 	"strings"
+	"sync"
 
 	"github.com/99designs/gqlgen/internal/code"
 
@@ -16,6 +17,9 @@ import (
 	"golang.org/x/tools/imports"
 )
 
+// localPrefixMu guards imports.LocalPrefix while Prune is formatting with it
+var localPrefixMu sync.Mutex
+
 type visitFn func(node ast.Node)
 
 func (fn visitFn) Visit(node ast.Node) ast.Visitor {
@@ -23,8 +27,9 @@ func (fn visitFn) Visit(node ast.Node) ast.Visitor {
 	return fn
 }
 
-// Prune removes any unused imports
-func Prune(filename string, src []byte) ([]byte, error) {
+// Prune removes any unused imports. localPrefix is a comma separated list of import path prefixes that are grouped
+// after the third party imports, like goimports -local.
+func Prune(filename string, src []byte, localPrefix string) ([]byte, error) {
 	fset := token.NewFileSet()
 
 	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.AllErrors)
@@ -46,6 +51,12 @@ func Prune(filename string, src []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	// this version of x/tools only takes the local prefix as a global, it is put back so an embedder's setting survives
+	localPrefixMu.Lock()
+	defer localPrefixMu.Unlock()
+	defer func(previous string) { imports.LocalPrefix = previous }(imports.LocalPrefix)
+	imports.LocalPrefix = localPrefix
+
 	return imports.Process(filename, buf.Bytes(), &imports.Options{FormatOnly: true, Comments: true, TabIndent: true, TabWidth: 8})
 }
 
@@ -5,14 +5,47 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	goimports "golang.org/x/tools/imports"
 )
 
 func TestPrune(t *testing.T) {
-	b, err := Prune("testdata/unused.go", mustReadFile("testdata/unused.go"))
+	b, err := Prune("testdata/unused.go", mustReadFile("testdata/unused.go"), "")
 	require.NoError(t, err)
 	require.Equal(t, string(mustReadFile("testdata/unused.expected.go")), string(b))
 }
 
+func TestPruneLocalPrefix(t *testing.T) {
+	src := `package local
+
+import (
+	"fmt"
+	"github.com/acme/app/models"
+	"github.com/vektah/gqlparser/ast"
+)
+
+var _ = fmt.Sprint(models.User{}, ast.Argument{})
+`
+
+	defer func(previous string) { goimports.LocalPrefix = previous }(goimports.LocalPrefix)
+	goimports.LocalPrefix = "github.com/embedder"
+
+	b, err := Prune("local.go", []byte(src), "github.com/acme/app")
+	require.NoError(t, err)
+	require.Equal(t, "github.com/embedder", goimports.LocalPrefix)
+	require.Equal(t, `package local
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/ast"
+
+	"github.com/acme/app/models"
+)
+
+var _ = fmt.Sprint(models.User{}, ast.Argument{})
+`, string(b))
+}
+
 func mustReadFile(filename string) []byte {
 	b, err := ioutil.ReadFile(filename)
 	if err != nil {
@@ -0,0 +1,100 @@
+package modelgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/99designs/gqlgen/codegen/config"
+	"github.com/vektah/gqlparser/ast"
+)
+
+type modelEdge struct {
+	model string
+	field *ast.FieldDefinition
+}
+
+// checkValueCycles reports generated objects that would contain themselves. Non-null object fields are generated as
+// values, so a chain of them leading back to where it started is a recursive struct that go can't compile. Input cycles
+// are already reported by codegen, as those inputs could never be constructed.
+func checkValueCycles(cfg *config.Config, schema *ast.Schema) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	isGenerated := func(def *ast.Definition) bool {
+		isRoot := def == schema.Query || def == schema.Mutation || def == schema.Subscription
+		return def.Kind == ast.Object && !isRoot && !cfg.Models.UserDefined(def.Name)
+	}
+
+	var errs []string
+	state := map[string]int{}
+	var path []modelEdge
+
+	var visit func(def *ast.Definition)
+	visit = func(def *ast.Definition) {
+		state[def.Name] = visiting
+		for _, field := range def.Fields {
+			if !field.Type.NonNull || field.Type.Elem != nil {
+				continue
+			}
+			next := schema.Types[field.Type.Name()]
+			if next == nil || !isGenerated(next) {
+				continue
+			}
+
+			path = append(path, modelEdge{model: def.Name, field: field})
+			switch state[next.Name] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				errs = append(errs, valueCycleError(path, next.Name))
+			}
+			path = path[:len(path)-1]
+		}
+		state[def.Name] = visited
+	}
+
+	names := make([]string, 0, len(schema.Types))
+	for name := range schema.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def := schema.Types[name]
+		if isGenerated(def) && state[name] == unvisited {
+			visit(def)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func valueCycleError(path []modelEdge, to string) string {
+	start := 0
+	for i, edge := range path {
+		if edge.model == to {
+			start = i
+			break
+		}
+	}
+
+	var steps []string
+	for _, edge := range path[start:] {
+		steps = append(steps, edge.model+"."+edge.field.Name)
+	}
+
+	first := path[start]
+	name := first.model + "." + first.field.Name
+	if pos := first.field.Position; pos != nil && pos.Src != nil {
+		name = fmt.Sprintf("%s:%d: %s", pos.Src.Name, pos.Line, name)
+	}
+	return fmt.Sprintf("%s: generated model would contain itself, non-null fields form a cycle: %s -> %s; make one of them nullable or bind one of the types to a model",
+		name, strings.Join(steps, " -> "), to)
+}
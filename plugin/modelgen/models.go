@@ -1,9 +1,14 @@
 package modelgen
 
 import (
+	"fmt"
 	"go/types"
+	"path"
 	"sort"
+	"strings"
+	"unicode"
 
+	"github.com/99designs/gqlgen/codegen"
 	"github.com/99designs/gqlgen/codegen/config"
 	"github.com/99designs/gqlgen/codegen/templates"
 	"github.com/99designs/gqlgen/internal/code"
@@ -22,20 +27,85 @@ type ModelBuild struct {
 type Interface struct {
 	Description string
 	Name        string
+	Fields      []*Field
 }
 
 type Object struct {
 	Description string
 	Name        string
 	Fields      []*Field
+	ExtraFields []*ExtraField
 	Implements  []string
+	Getters     []*Getter
 }
 
 type Field struct {
-	Description string
-	Name        string
-	Type        types.Type
-	Tag         string
+	Description       string
+	Name              string
+	Type              types.Type
+	Tag               string
+	IsDeprecated      bool
+	DeprecationReason string
+}
+
+// ExtraField is a go only field from the model's extra_fields config, it is written out exactly as configured
+type ExtraField struct {
+	Name string
+	Type types.Type
+	Tag  string
+}
+
+// Getter is generated on a model so that it satisfies a field getter on one of the generated interfaces it implements
+type Getter struct {
+	Name      string
+	Type      types.Type
+	Field     string
+	FieldType types.Type
+}
+
+// Return is the expression converting the model field into the type declared by the interface
+func (g *Getter) Return() string {
+	return convertGetter("this."+g.Field, g.FieldType, g.Type, 0)
+}
+
+// convertGetter handles implementors declaring a stricter type than their interface, eg a non-null field implementing a
+// nullable one, or a list of objects implementing a list of interfaces.
+func convertGetter(expr string, from types.Type, to types.Type, depth int) string {
+	if sameType(from, to) {
+		return expr
+	}
+
+	if ptr, ok := to.(*types.Pointer); ok && sameType(ptr.Elem(), from) {
+		return "&" + expr
+	}
+
+	fromSlice, fromIsSlice := from.(*types.Slice)
+	toSlice, toIsSlice := to.(*types.Slice)
+	if fromIsSlice && toIsSlice {
+		typ := templates.CurrentImports.LookupType(to)
+		res := fmt.Sprintf("res%d", depth)
+		idx := fmt.Sprintf("i%d", depth)
+
+		return fmt.Sprintf("func() %s {\n"+
+			"if %s == nil {\nreturn nil\n}\n"+
+			"%s := make(%s, len(%s))\n"+
+			"for %s := range %s {\n%s[%s] = %s\n}\n"+
+			"return %s\n"+
+			"}()",
+			typ,
+			expr,
+			res, typ, expr,
+			idx, expr, res, idx, convertGetter(expr+"["+idx+"]", fromSlice.Elem(), toSlice.Elem(), depth+1),
+			res,
+		)
+	}
+
+	// anything else must be directly assignable, eg an object implementing a generated interface
+	return expr
+}
+
+func sameType(a types.Type, b types.Type) bool {
+	return types.TypeString(a, nil) == types.TypeString(b, nil)
 }
 
 type Enum struct {
@@ -45,8 +115,10 @@ type Enum struct {
 }
 
 type EnumValue struct {
-	Description string
-	Name        string
+	Description       string
+	Name              string
+	IsDeprecated      bool
+	DeprecationReason string
 }
 
 func New() plugin.Plugin {
@@ -73,6 +145,18 @@ func (m *Plugin) MutateConfig(cfg *config.Config) error {
 
 	cfg.InjectBuiltins(schema)
 
+	if err := cfg.Autobind(schema); err != nil {
+		return err
+	}
+
+	if err := checkValueCycles(cfg, schema); err != nil {
+		return err
+	}
+
+	if err := checkExtraFields(cfg, schema); err != nil {
+		return err
+	}
+
 	binder, err := cfg.NewBinder(schema)
 	if err != nil {
 		return err
@@ -82,6 +166,9 @@ func (m *Plugin) MutateConfig(cfg *config.Config) error {
 		PackageName: cfg.Model.Package,
 	}
 
+	// model fields keyed by their schema name, used to match them up with the interface getters
+	modelFields := map[string]map[string]*Field{}
+
 	for _, schemaType := range schema.Types {
 		if cfg.Models.UserDefined(schemaType.Name) {
 			continue
@@ -94,6 +181,16 @@ func (m *Plugin) MutateConfig(cfg *config.Config) error {
 				Name:        schemaType.Name,
 			}
 
+			if !hasUserDefinedImplementors(cfg, schema, schemaType) {
+				for _, field := range schemaType.Fields {
+					f, err := buildField(cfg, binder, schema, schemaType, field)
+					if err != nil {
+						return err
+					}
+					it.Fields = append(it.Fields, f)
+				}
+			}
+
 			b.Interfaces = append(b.Interfaces, it)
 		case ast.Object, ast.InputObject:
 			if schemaType == schema.Query || schemaType == schema.Mutation || schemaType == schema.Subscription {
@@ -103,60 +200,27 @@ func (m *Plugin) MutateConfig(cfg *config.Config) error {
 				Description: schemaType.Description,
 				Name:        schemaType.Name,
 			}
+			fields := map[string]*Field{}
 
 			for _, implementor := range schema.GetImplements(schemaType) {
 				it.Implements = append(it.Implements, implementor.Name)
 			}
 
 			for _, field := range schemaType.Fields {
-				var typ types.Type
-
-				if cfg.Models.UserDefined(field.Type.Name()) {
-					pkg, typeName := code.PkgAndType(cfg.Models[field.Type.Name()].Model[0])
-					typ, err = binder.FindType(pkg, typeName)
-					if err != nil {
-						return err
-					}
-				} else {
-					fieldDef := schema.Types[field.Type.Name()]
-					switch fieldDef.Kind {
-					case ast.Scalar:
-						// no user defined model, referencing a default scalar
-						typ = types.NewNamed(
-							types.NewTypeName(0, cfg.Model.Pkg(), "string", nil),
-							nil,
-							nil,
-						)
-					case ast.Interface, ast.Union:
-						// no user defined model, referencing a generated interface type
-						typ = types.NewNamed(
-							types.NewTypeName(0, cfg.Model.Pkg(), templates.ToGo(field.Type.Name()), nil),
-							types.NewInterfaceType([]*types.Func{}, []types.Type{}),
-							nil,
-						)
-					default:
-						// no user defined model, must reference another generated model
-						typ = types.NewNamed(
-							types.NewTypeName(0, cfg.Model.Pkg(), templates.ToGo(field.Type.Name()), nil),
-							nil,
-							nil,
-						)
-					}
-				}
-
-				name := field.Name
-				if nameOveride := cfg.Models[schemaType.Name].Fields[field.Name].FieldName; nameOveride != "" {
-					name = nameOveride
+				f, err := buildField(cfg, binder, schema, schemaType, field)
+				if err != nil {
+					return err
 				}
+				it.Fields = append(it.Fields, f)
+				fields[field.Name] = f
+			}
 
-				it.Fields = append(it.Fields, &Field{
-					Name:        name,
-					Type:        binder.CopyModifiersFromAst(field.Type, typ),
-					Description: field.Description,
-					Tag:         `json:"` + field.Name + `"`,
-				})
+			it.ExtraFields, err = buildExtraFields(cfg.Models[it.Name].ExtraFields, it)
+			if err != nil {
+				return err
 			}
 
+			modelFields[it.Name] = fields
 			b.Models = append(b.Models, it)
 		case ast.Enum:
 			it := &Enum{
@@ -165,10 +229,13 @@ func (m *Plugin) MutateConfig(cfg *config.Config) error {
 			}
 
 			for _, v := range schemaType.EnumValues {
-				it.Values = append(it.Values, &EnumValue{
+				value := &EnumValue{
 					Name:        v.Name,
 					Description: v.Description,
-				})
+				}
+				value.DeprecationReason, value.IsDeprecated = codegen.DeprecationReason(v.Directives)
+
+				it.Values = append(it.Values, value)
 			}
 
 			b.Enums = append(b.Enums, it)
@@ -177,8 +244,53 @@ func (m *Plugin) MutateConfig(cfg *config.Config) error {
 		}
 	}
 
-	sort.Slice(b.Enums, func(i, j int) bool { return b.Enums[i].Name < b.Enums[j].Name })
+	interfaces := map[string]*Interface{}
+	for _, it := range b.Interfaces {
+		interfaces[it.Name] = it
+	}
+
+	// sorted first so conflicting getters are reported in the same order every time
 	sort.Slice(b.Models, func(i, j int) bool { return b.Models[i].Name < b.Models[j].Name })
+
+	for _, it := range b.Models {
+		// the interface each getter was added for, so conflicting declarations can be reported
+		getters := map[string]*ast.Definition{}
+		getterTypes := map[string]types.Type{}
+		for _, implements := range it.Implements {
+			iface, ok := interfaces[implements]
+			if !ok || len(iface.Fields) == 0 {
+				// user defined interfaces and those without getters only get the marker method
+				continue
+			}
+
+			// interface fields are built in schema order
+			for i, schemaField := range schema.Types[iface.Name].Fields {
+				modelField := modelFields[it.Name][schemaField.Name]
+				ifaceField := iface.Fields[i]
+				name := "Get" + templates.ToGo(ifaceField.Name)
+				if modelField == nil {
+					continue
+				}
+				if prev, ok := getters[name]; ok {
+					if !types.Identical(getterTypes[name], ifaceField.Type) {
+						return getterConflictError(schema.Types[it.Name], prev, schema.Types[iface.Name], schemaField.Name)
+					}
+					continue
+				}
+				getters[name] = schema.Types[iface.Name]
+				getterTypes[name] = ifaceField.Type
+
+				it.Getters = append(it.Getters, &Getter{
+					Name:      name,
+					Type:      ifaceField.Type,
+					Field:     templates.ToGo(modelField.Name),
+					FieldType: modelField.Type,
+				})
+			}
+		}
+	}
+
+	sort.Slice(b.Enums, func(i, j int) bool { return b.Enums[i].Name < b.Enums[j].Name })
 	sort.Slice(b.Interfaces, func(i, j int) bool { return b.Interfaces[i].Name < b.Interfaces[j].Name })
 
 	for _, it := range b.Enums {
@@ -203,5 +315,194 @@ func (m *Plugin) MutateConfig(cfg *config.Config) error {
 		Filename:        cfg.Model.Filename,
 		Data:            b,
 		GeneratedHeader: true,
+		Header:          cfg.Header.String(),
+		TemplateDir:     cfg.TemplateDir,
+		LocalPrefix:     cfg.LocalPrefix,
+		SkipFormat:      cfg.SkipFormat,
+		Files:           cfg.Files,
 	})
 }
+
+// hasUserDefinedImplementors reports whether any implementor is bound to an existing model. Those may resolve interface
+// fields through resolvers rather than struct fields, so the generated interface keeps only its marker method.
+func hasUserDefinedImplementors(cfg *config.Config, schema *ast.Schema, schemaType *ast.Definition) bool {
+	for _, implementor := range schema.GetPossibleTypes(schemaType) {
+		if cfg.Models.UserDefined(implementor.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func buildField(cfg *config.Config, binder *config.Binder, schema *ast.Schema, schemaType *ast.Definition, field *ast.FieldDefinition) (*Field, error) {
+	var typ types.Type
+	var err error
+
+	if cfg.Models.UserDefined(field.Type.Name()) {
+		model := cfg.Models[field.Type.Name()].Model[0]
+		if inputModel := cfg.Models[field.Type.Name()].InputModel; schemaType.Kind == ast.InputObject && len(inputModel) > 0 {
+			model = inputModel[0]
+		}
+		pkg, typeName := code.PkgAndType(model)
+		typ, err = binder.FindType(pkg, typeName)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		fieldDef := schema.Types[field.Type.Name()]
+		switch fieldDef.Kind {
+		case ast.Scalar:
+			// no user defined model, referencing a default scalar
+			typ = types.NewNamed(
+				types.NewTypeName(0, cfg.Model.Pkg(), "string", nil),
+				nil,
+				nil,
+			)
+		case ast.Interface, ast.Union:
+			// no user defined model, referencing a generated interface type
+			typ = types.NewNamed(
+				types.NewTypeName(0, cfg.Model.Pkg(), templates.ToGo(field.Type.Name()), nil),
+				types.NewInterfaceType([]*types.Func{}, []types.Type{}),
+				nil,
+			)
+		default:
+			// no user defined model, must reference another generated model
+			typ = types.NewNamed(
+				types.NewTypeName(0, cfg.Model.Pkg(), templates.ToGo(field.Type.Name()), nil),
+				nil,
+				nil,
+			)
+		}
+	}
+
+	name := field.Name
+	if nameOveride := cfg.Models[schemaType.Name].Fields[field.Name].FieldName; nameOveride != "" {
+		name = nameOveride
+	}
+
+	f := &Field{
+		Name:        name,
+		Type:        binder.CopyModifiersFromAst(field.Type, typ),
+		Description: field.Description,
+		Tag:         jsonTag(cfg.JSONTagsFor(schemaType.Name), field),
+	}
+	f.DeprecationReason, f.IsDeprecated = codegen.DeprecationReason(field.Directives)
+
+	return f, nil
+}
+
+// checkExtraFields makes sure extra_fields are only configured for objects and inputs that are generated.
+func checkExtraFields(cfg *config.Config, schema *ast.Schema) error {
+	var names []string
+	for name, entry := range cfg.Models {
+		if len(entry.ExtraFields) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def := schema.Types[name]
+		isRoot := def != nil && (def == schema.Query || def == schema.Mutation || def == schema.Subscription)
+		if def == nil || (def.Kind != ast.Object && def.Kind != ast.InputObject) || isRoot || cfg.Models.UserDefined(name) {
+			return fmt.Errorf("model %s: extra_fields can only be added to generated objects and inputs", name)
+		}
+	}
+	return nil
+}
+
+// buildExtraFields builds the extra fields of a model sorted by name. Their names can't match any of the fields from the
+// schema, ignoring case, so that codegen can never bind a schema field to one of them.
+func buildExtraFields(extra map[string]config.ModelExtraField, model *Object) ([]*ExtraField, error) {
+	var fields []*ExtraField
+	for name, field := range extra {
+		for _, f := range model.Fields {
+			if strings.EqualFold(name, templates.ToGo(f.Name)) || strings.EqualFold(name, f.Name) {
+				return nil, fmt.Errorf("model %s: extra_fields: %s collides with the schema field %s", model.Name, name, f.Name)
+			}
+		}
+
+		typ, err := extraFieldType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("model %s: extra_fields: %s: %s", model.Name, name, err.Error())
+		}
+		fields = append(fields, &ExtraField{Name: name, Type: typ, Tag: field.Tag})
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields, nil
+}
+
+// extraFieldType turns a type like *github.com/my/app/db.Key or []string into a go type that the template can reference.
+func extraFieldType(typ string) (types.Type, error) {
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		elem, err := extraFieldType(typ[1:])
+		if err != nil {
+			return nil, err
+		}
+		return types.NewPointer(elem), nil
+	case strings.HasPrefix(typ, "[]"):
+		elem, err := extraFieldType(typ[2:])
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSlice(elem), nil
+	}
+
+	pkg, name := code.PkgAndType(typ)
+	if pkg == "" {
+		if builtin, ok := types.Universe.Lookup(name).(*types.TypeName); ok {
+			return builtin.Type(), nil
+		}
+		return nil, fmt.Errorf("%s is not a builtin type, it needs to be qualified by its import path", typ)
+	}
+	return types.NewNamed(types.NewTypeName(0, types.NewPackage(pkg, path.Base(pkg)), name, nil), nil, nil), nil
+}
+
+func jsonTag(tags config.JSONTags, field *ast.FieldDefinition) string {
+	name := field.Name
+	switch tags.Naming {
+	case config.JSONTagNamingNone:
+		return ""
+	case config.JSONTagNamingSnakeCase:
+		name = snakeCase(name)
+	}
+
+	if tags.OmitEmpty && !field.Type.NonNull {
+		name += ",omitempty"
+	}
+	return `json:"` + name + `"`
+}
+
+// snakeCase converts a graphql name like userID or HTTPStatus to user_id or http_status
+func snakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' {
+				prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+				endOfInitialism := unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if prevLower || endOfInitialism {
+					b.WriteRune('_')
+				}
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// getterConflictError is returned when two interfaces implemented by a model declare the same field with different
+// types, as the model can only have one getter for it.
+func getterConflictError(model, first, second *ast.Definition, field string) error {
+	name := model.Name
+	if pos := model.Position; pos != nil && pos.Src != nil {
+		name = fmt.Sprintf("%s:%d: %s", pos.Src.Name, pos.Line, name)
+	}
+	return fmt.Errorf("%s implements %s and %s, which declare %s as %s and %s; a generated model can only have one getter for it",
+		name, first.Name, second.Name, field, first.Fields.ForName(field).Type.String(), second.Fields.ForName(field).Type.String())
+}
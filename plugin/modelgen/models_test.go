@@ -1,10 +1,14 @@
 package modelgen
 
 import (
+	"io/ioutil"
 	"testing"
 
 	"github.com/99designs/gqlgen/codegen/config"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/plugin/modelgen/out"
 	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/ast"
 )
 
 func TestModelGeneration(t *testing.T) {
@@ -17,4 +21,104 @@ func TestModelGeneration(t *testing.T) {
 	require.True(t, cfg.Models.UserDefined("MissingEnum"))
 	require.True(t, cfg.Models.UserDefined("MissingUnion"))
 	require.True(t, cfg.Models.UserDefined("MissingInterface"))
+
+	t.Run("deprecated fields and enum values are commented", func(t *testing.T) {
+		generated, err := ioutil.ReadFile("out/generated.go")
+		require.NoError(t, err)
+
+		require.Contains(t, string(generated), "\t// an old name\n\t//\n\t// Deprecated: use name\n\tOldName *string")
+		require.Contains(t, string(generated), "\t// Deprecated: No longer supported\n\tMissingEnumFarewell MissingEnum")
+	})
+
+	t.Run("extra fields are added after the schema fields", func(t *testing.T) {
+		input := out.MissingInput{Tags: []string{"a"}, DBKey: &graphql.Response{}}
+		require.Equal(t, []string{"a"}, input.Tags)
+
+		generated, err := ioutil.ReadFile("out/generated.go")
+		require.NoError(t, err)
+		require.Contains(t, string(generated), "\tDBKey    *graphql.Response `db:\"key\"`\n\tTags     []string\n\tloadedAt time.Time\n}")
+	})
+}
+
+func TestModelGenerationExtraFields(t *testing.T) {
+	generate := func(models config.TypeMap) error {
+		cfg := config.DefaultConfig()
+		cfg.SchemaFilename = config.StringList{"testdata/schema.graphql"}
+		cfg.Exec = config.PackageConfig{Filename: "out/ignored.go"}
+		cfg.Model = config.PackageConfig{Filename: "out/extra.go"}
+		cfg.Models = models
+		p := Plugin{}
+		return p.MutateConfig(cfg)
+	}
+
+	err := generate(config.TypeMap{"MissingType": {ExtraFields: map[string]config.ModelExtraField{"NAME": {Type: "string"}}}})
+	require.EqualError(t, err, "model MissingType: extra_fields: NAME collides with the schema field name")
+
+	err = generate(config.TypeMap{"MissingType": {ExtraFields: map[string]config.ModelExtraField{"key": {Type: "Key"}}}})
+	require.EqualError(t, err, "model MissingType: extra_fields: key: Key is not a builtin type, it needs to be qualified by its import path")
+
+	err = generate(config.TypeMap{"MissingEnum": {ExtraFields: map[string]config.ModelExtraField{"key": {Type: "string"}}}})
+	require.EqualError(t, err, "model MissingEnum: extra_fields can only be added to generated objects and inputs")
+
+	err = generate(config.TypeMap{"MissingType": {ExtraFields: map[string]config.ModelExtraField{"key": {}}}})
+	require.EqualError(t, err, "config.models: model MissingType: extra_fields: key: type is required")
+}
+
+func TestModelGenerationValueCycles(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SchemaFilename = config.StringList{"testdata/cycles.graphql"}
+	cfg.Exec = config.PackageConfig{Filename: "out/ignored.go"}
+	cfg.Model = config.PackageConfig{Filename: "out/cycles.go"}
+
+	p := Plugin{}
+	err := p.MutateConfig(cfg)
+	require.EqualError(t, err, "testdata/cycles.graphql:12: Next.node: generated model would contain itself, non-null fields form a cycle: Next.node -> Node.next -> Next; make one of them nullable or bind one of the types to a model\n"+
+		"testdata/cycles.graphql:16: Self.self: generated model would contain itself, non-null fields form a cycle: Self.self -> Self; make one of them nullable or bind one of the types to a model")
+}
+
+func TestModelGenerationGetterConflicts(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SchemaFilename = config.StringList{"testdata/getters.graphql"}
+	cfg.Exec = config.PackageConfig{Filename: "out/ignored.go"}
+	cfg.Model = config.PackageConfig{Filename: "out/getters.go"}
+
+	p := Plugin{}
+	err := p.MutateConfig(cfg)
+	require.EqualError(t, err, "testdata/getters.graphql:13: Book implements Named and Titled, which declare name as String and String!; a generated model can only have one getter for it")
+}
+
+func TestModelGettersSatisfyInterfaces(t *testing.T) {
+	name := "name"
+	var missing out.MissingInterface = out.MissingType{Name: &name}
+	require.Equal(t, &name, missing.GetName())
+
+	var foo out.FooBarer = out.FooBarr{Name: "foo", Things: []out.MissingType{{Name: &name}}}
+	require.Equal(t, "foo", *foo.GetName())
+	require.Len(t, foo.GetThings(), 1)
+	require.Equal(t, &name, foo.GetThings()[0].GetName())
+	require.Nil(t, out.FooBarr{}.GetThings())
+}
+
+func TestJSONTag(t *testing.T) {
+	userID := &ast.FieldDefinition{Name: "userID", Type: ast.NamedType("ID", nil)}
+	httpStatus := &ast.FieldDefinition{Name: "HTTPStatus", Type: ast.NonNullNamedType("Int", nil)}
+
+	tests := []struct {
+		Name     string
+		Tags     config.JSONTags
+		Field    *ast.FieldDefinition
+		Expected string
+	}{
+		{"defaults to the graphql name", config.JSONTags{}, userID, `json:"userID"`},
+		{"graphql naming", config.JSONTags{Naming: config.JSONTagNamingGraphQL}, httpStatus, `json:"HTTPStatus"`},
+		{"snake case naming", config.JSONTags{Naming: config.JSONTagNamingSnakeCase}, userID, `json:"user_id"`},
+		{"snake case naming with a leading initialism", config.JSONTags{Naming: config.JSONTagNamingSnakeCase}, httpStatus, `json:"http_status"`},
+		{"no tag", config.JSONTags{Naming: config.JSONTagNamingNone, OmitEmpty: true}, userID, ``},
+		{"omitempty on nullable fields", config.JSONTags{OmitEmpty: true}, userID, `json:"userID,omitempty"`},
+		{"no omitempty on non-null fields", config.JSONTags{OmitEmpty: true}, httpStatus, `json:"HTTPStatus"`},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.Expected, jsonTag(tt.Tags, tt.Field), tt.Name)
+	}
 }
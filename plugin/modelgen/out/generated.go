@@ -6,10 +6,20 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 )
 
+type FooBarer interface {
+	IsFooBarer()
+	GetName() *string
+	GetThings() []MissingInterface
+}
+
 type MissingInterface interface {
 	IsMissingInterface()
+	GetName() *string
 }
 
 type MissingUnion interface {
@@ -23,14 +33,38 @@ type ExistingType struct {
 	Existing *MissingType      `json:"existing"`
 }
 
-func (ExistingType) IsMissingUnion()      {}
-func (ExistingType) IsMissingInterface()  {}
-func (ExistingType) IsExistingInterface() {}
-func (ExistingType) IsExistingUnion()     {}
+func (ExistingType) IsMissingUnion()       {}
+func (ExistingType) IsMissingInterface()   {}
+func (ExistingType) IsExistingInterface()  {}
+func (ExistingType) IsExistingUnion()      {}
+func (this ExistingType) GetName() *string { return this.Name }
+
+type FooBarr struct {
+	Name   string        `json:"name"`
+	Things []MissingType `json:"things"`
+}
+
+func (FooBarr) IsFooBarer()           {}
+func (this FooBarr) GetName() *string { return &this.Name }
+func (this FooBarr) GetThings() []MissingInterface {
+	return func() []MissingInterface {
+		if this.Things == nil {
+			return nil
+		}
+		res0 := make([]MissingInterface, len(this.Things))
+		for i0 := range this.Things {
+			res0[i0] = this.Things[i0]
+		}
+		return res0
+	}()
+}
 
 type MissingInput struct {
-	Name *string      `json:"name"`
-	Enum *MissingEnum `json:"enum"`
+	Name     *string           `json:"name"`
+	Enum     *MissingEnum      `json:"enum"`
+	DBKey    *graphql.Response `db:"key"`
+	Tags     []string
+	loadedAt time.Time
 }
 
 type MissingType struct {
@@ -38,28 +72,36 @@ type MissingType struct {
 	Enum     *MissingEnum     `json:"enum"`
 	Int      MissingInterface `json:"int"`
 	Existing *ExistingType    `json:"existing"`
+	// an old name
+	//
+	// Deprecated: use name
+	OldName *string `json:"oldName"`
 }
 
-func (MissingType) IsMissingInterface()  {}
-func (MissingType) IsExistingInterface() {}
-func (MissingType) IsMissingUnion()      {}
-func (MissingType) IsExistingUnion()     {}
+func (MissingType) IsMissingInterface()   {}
+func (MissingType) IsExistingInterface()  {}
+func (MissingType) IsMissingUnion()       {}
+func (MissingType) IsExistingUnion()      {}
+func (this MissingType) GetName() *string { return this.Name }
 
 type MissingEnum string
 
 const (
 	MissingEnumHello   MissingEnum = "Hello"
 	MissingEnumGoodbye MissingEnum = "Goodbye"
+	// Deprecated: No longer supported
+	MissingEnumFarewell MissingEnum = "Farewell"
 )
 
 var AllMissingEnum = []MissingEnum{
 	MissingEnumHello,
 	MissingEnumGoodbye,
+	MissingEnumFarewell,
 }
 
 func (e MissingEnum) IsValid() bool {
 	switch e {
-	case MissingEnumHello, MissingEnumGoodbye:
+	case MissingEnumHello, MissingEnumGoodbye, MissingEnumFarewell:
 		return true
 	}
 	return false
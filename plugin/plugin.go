@@ -15,6 +15,11 @@ type ConfigMutator interface {
 	MutateConfig(cfg *config.Config) error
 }
 
+// DataMutator is called with the unified data model after it has been built, but before any code is generated.
+type DataMutator interface {
+	MutateData(cfg *config.Config, data *codegen.Data) error
+}
+
 type CodeGenerator interface {
 	GenerateCode(cfg *codegen.Data) error
 }
@@ -3,11 +3,15 @@ package resolvergen
 import (
 	"log"
 	"os"
+	"unicode"
 
 	"github.com/99designs/gqlgen/codegen"
+	"github.com/99designs/gqlgen/codegen/config"
 	"github.com/99designs/gqlgen/codegen/templates"
+	"github.com/99designs/gqlgen/internal/code"
 	"github.com/99designs/gqlgen/plugin"
 	"github.com/pkg/errors"
+	"github.com/vektah/gqlparser/ast"
 )
 
 func New() plugin.Plugin {
@@ -26,23 +30,171 @@ func (m *Plugin) GenerateCode(data *codegen.Data) error {
 		return nil
 	}
 
-	resolverBuild := &ResolverBuild{
-		Data:         data,
-		PackageName:  data.Config.Resolver.Package,
-		ResolverType: data.Config.Resolver.Type,
+	if data.Config.Resolver.Layout == config.LayoutFollowSchema {
+		return m.generateFollowSchema(data)
 	}
+	return m.generateSingleFile(data)
+}
+
+func (m *Plugin) generateSingleFile(data *codegen.Data) error {
 	filename := data.Config.Resolver.Filename
 
-	if _, err := os.Stat(filename); os.IsNotExist(errors.Cause(err)) {
-		return templates.Render(templates.Options{
-			PackageName: data.Config.Resolver.Package,
-			Filename:    data.Config.Resolver.Filename,
-			Data:        resolverBuild,
-		})
+	if _, err := os.Stat(filename); !os.IsNotExist(errors.Cause(err)) {
+		log.Printf("Skipped resolver: %s already exists\n", filename)
+		return nil
+	}
+
+	file := &File{DeclareResolver: true}
+	for _, o := range data.Objects {
+		if !o.HasResolvers() {
+			continue
+		}
+		object := file.object(o)
+		object.Declare = true
+		for _, f := range o.Fields {
+			if f.IsResolver {
+				object.Resolvers = append(object.Resolvers, &Resolver{Field: f, Implementation: notImplemented})
+			}
+		}
+	}
+
+	return render(data, filename, file, nil)
+}
+
+// generateFollowSchema writes the resolvers for each schema file into their own file, keeping the implementations
+// that are already there. The resolver type itself is only written to resolver.filename when it doesn't exist yet.
+func (m *Plugin) generateFollowSchema(data *codegen.Data) error {
+	cfg := data.Config.Resolver
+
+	existing, err := readResolverFiles(cfg.ResolverFilename("*"))
+	if err != nil {
+		return err
+	}
+
+	var filenames []string
+	files := map[string]*File{}
+	fileFor := func(pos *ast.Position, fallback string) *File {
+		filename := fallback
+		if pos != nil && pos.Src != nil {
+			filename = cfg.ResolverFilename(pos.Src.Name)
+		}
+		if files[filename] == nil {
+			filenames = append(filenames, filename)
+			files[filename] = &File{Regenerated: true}
+		}
+		return files[filename]
+	}
+
+	for _, o := range data.Objects {
+		if !o.HasResolvers() {
+			continue
+		}
+		objectFilename := cfg.ResolverFilename(o.Position.Src.Name)
+		fileFor(o.Position, objectFilename).object(o).Declare = true
+		existing.generated(cfg.Type + "." + o.Name)
+		existing.generated(resolverTypeName(o))
+
+		for _, f := range o.Fields {
+			if !f.IsResolver {
+				continue
+			}
+			object := fileFor(f.Position, objectFilename).object(o)
+			resolver := &Resolver{Field: f, Implementation: notImplemented}
+			if impl := existing.implementation(resolverTypeName(o) + "." + f.GoFieldName); impl != nil {
+				resolver.Comment = impl.comment
+				resolver.Implementation = impl.body
+			}
+			object.Resolvers = append(object.Resolvers, resolver)
+		}
+	}
+
+	// files that no longer match a schema file are rewritten with whatever wasn't generated elsewhere, or removed when
+	// nothing is left
+	for _, filename := range existing.filenames {
+		if files[filename] != nil {
+			continue
+		}
+		if existing.remaining(filename) == "" {
+			if err := templates.Remove(filename); err != nil {
+				return err
+			}
+			continue
+		}
+		filenames = append(filenames, filename)
+		files[filename] = &File{Regenerated: true}
+	}
+
+	for _, filename := range filenames {
+		file := files[filename]
+		file.RemainingSource = existing.remaining(filename)
+		if err := render(data, filename, file, existing.imports); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(cfg.Filename); !os.IsNotExist(errors.Cause(err)) {
+		return nil
+	}
+	return render(data, cfg.Filename, &File{DeclareResolver: true}, nil)
+}
+
+const notImplemented = "{\n\tpanic(\"not implemented\")\n}"
+
+// defaultImports are always reserved, so resolvers written by hand can use them without needing to add an import
+var defaultImports = []string{
+	"context",
+	"fmt",
+	"io",
+	"strconv",
+	"time",
+	"sync",
+	"errors",
+	"bytes",
+	"github.com/99designs/gqlgen/handler",
+	"github.com/vektah/gqlparser",
+	"github.com/vektah/gqlparser/ast",
+	"github.com/99designs/gqlgen/graphql",
+	"github.com/99designs/gqlgen/graphql/introspection",
+}
+
+func render(data *codegen.Data, filename string, file *File, imports []*Import) error {
+	// the imports of existing code win over the defaults, so it keeps compiling
+	file.Imports = append(file.Imports, imports...)
+	for _, path := range defaultImports {
+		if !hasImport(file.Imports, path, code.NameForPackage(path)) {
+			file.Imports = append(file.Imports, &Import{Path: path})
+		}
+	}
+
+	return templates.Render(templates.Options{
+		PackageName: data.Config.Resolver.Package,
+		Filename:    filename,
+		Header:      data.Config.Header.String(),
+		TemplateDir: data.Config.TemplateDir,
+		LocalPrefix: data.Config.LocalPrefix,
+		SkipFormat:  data.Config.SkipFormat,
+		Files:       data.Config.Files,
+		Data: &ResolverBuild{
+			Data:         data,
+			PackageName:  data.Config.Resolver.Package,
+			ResolverType: data.Config.Resolver.Type,
+			File:         file,
+		},
+	})
+}
+
+func hasImport(imports []*Import, path string, name string) bool {
+	for _, imp := range imports {
+		if imp.Path == path || imp.name() == name {
+			return true
+		}
 	}
+	return false
+}
 
-	log.Printf("Skipped resolver: %s already exists\n", filename)
-	return nil
+func resolverTypeName(o *codegen.Object) string {
+	name := []rune(o.Name)
+	return string(unicode.ToLower(name[0])) + string(name[1:]) + "Resolver"
 }
 
 type ResolverBuild struct {
@@ -50,4 +202,56 @@ type ResolverBuild struct {
 
 	PackageName  string
 	ResolverType string
+	File         *File
+}
+
+// File is a single resolver file
+type File struct {
+	// Regenerated files are rewritten every time, other files are only written when they don't exist yet
+	Regenerated bool
+	// DeclareResolver adds the resolver type to the file
+	DeclareResolver bool
+	Objects         []*FileObject
+	Imports         []*Import
+	// RemainingSource is the code that was in the file but isn't generated any more, eg the resolvers of removed fields
+	RemainingSource string
+}
+
+func (f *File) object(o *codegen.Object) *FileObject {
+	for _, existing := range f.Objects {
+		if existing.Object == o {
+			return existing
+		}
+	}
+	object := &FileObject{Object: o}
+	f.Objects = append(f.Objects, object)
+	return object
+}
+
+// FileObject is the part of an object's resolvers that go into a file
+type FileObject struct {
+	*codegen.Object
+
+	// Declare adds the resolver root method and the resolver type for the object, they go in the file for the schema
+	// the object is defined in
+	Declare   bool
+	Resolvers []*Resolver
+}
+
+type Resolver struct {
+	Field          *codegen.Field
+	Comment        string
+	Implementation string
+}
+
+type Import struct {
+	Path  string
+	Alias string
+}
+
+func (i *Import) name() string {
+	if i.Alias != "" {
+		return i.Alias
+	}
+	return code.NameForPackage(i.Path)
 }
@@ -0,0 +1,111 @@
+package resolvergen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/codegen"
+	"github.com/99designs/gqlgen/codegen/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollowSchemaLayout(t *testing.T) {
+	const dir = "testdata/followschema/out"
+	require.NoError(t, os.RemoveAll(dir))
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	defer os.RemoveAll(dir)
+
+	generate := func(version string) {
+		cfg := config.DefaultConfig()
+		cfg.SchemaFilename = config.StringList{
+			"testdata/followschema/" + version + "/user.graphql",
+			"testdata/followschema/" + version + "/todo.graphql",
+		}
+		cfg.Exec = config.PackageConfig{Filename: dir + "/generated.go", Package: "out"}
+		cfg.Model = config.PackageConfig{Filename: dir + "/models.go", Package: "out"}
+		cfg.Resolver = config.PackageConfig{Filename: dir + "/resolver.go", Package: "out", Type: "Resolver", Layout: config.LayoutFollowSchema}
+
+		data, err := codegen.BuildData(cfg)
+		require.NoError(t, err)
+		require.NoError(t, New().(*Plugin).GenerateCode(data))
+	}
+
+	read := func(filename string) string {
+		b, err := ioutil.ReadFile(filepath.Join(dir, filename))
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	write := func(filename string, src string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, filename), []byte(src), 0644))
+	}
+
+	generate("v1")
+
+	t.Run("resolvers are written to the file for their schema", func(t *testing.T) {
+		require.Contains(t, read("resolver.go"), "type Resolver struct{}")
+
+		user := read("user.resolvers.go")
+		require.Contains(t, user, "func (r *Resolver) Query() QueryResolver {")
+		require.Contains(t, user, "type queryResolver struct{ *Resolver }")
+		require.Contains(t, user, "func (r *queryResolver) User(ctx context.Context, id string) (string, error) {\n\tpanic(\"not implemented\")\n}")
+		require.NotContains(t, user, "Todos")
+
+		todo := read("todo.resolvers.go")
+		require.Contains(t, todo, "func (r *Resolver) Mutation() MutationResolver {")
+		require.Contains(t, todo, "func (r *queryResolver) Todos(ctx context.Context) ([]string, error) {")
+		require.Contains(t, todo, "func (r *mutationResolver) CreateTodo(ctx context.Context, text string) (string, error) {")
+		require.NotContains(t, todo, "type queryResolver")
+	})
+
+	todo := read("todo.resolvers.go")
+	todo = strings.Replace(todo, "Todos(ctx context.Context) ([]string, error) {\n\tpanic(\"not implemented\")", "Todos(ctx context.Context) ([]string, error) {\n\treturn todos(), nil", 1)
+	todo = strings.Replace(todo, "CreateTodo(ctx context.Context, text string) (string, error) {\n\tpanic(\"not implemented\")", "CreateTodo(ctx context.Context, text string) (string, error) {\n\treturn strings.ToUpper(text), nil", 1)
+	todo = strings.Replace(todo, "func (r *mutationResolver) CreateTodo", "// CreateTodo shouts\nfunc (r *mutationResolver) CreateTodo", 1)
+	todo += "\nfunc todos() []string {\n\treturn []string{\"a\"}\n}\n"
+	todo = strings.Replace(todo, "import (", "import (\n\t\"strings\"", 1)
+	write("todo.resolvers.go", todo)
+	write("resolver.go", "package out\n\ntype Resolver struct {\n\tdb string\n}\n")
+
+	generate("v2")
+
+	t.Run("implementations are kept when regenerating", func(t *testing.T) {
+		user := read("user.resolvers.go")
+		require.Contains(t, user, "// CreateTodo shouts\nfunc (r *mutationResolver) CreateTodo(ctx context.Context, text string) (string, error) {\n\treturn strings.ToUpper(text), nil\n}")
+		require.Contains(t, user, "\t\"strings\"\n")
+
+		todo := read("todo.resolvers.go")
+		require.Contains(t, todo, "func (r *mutationResolver) DeleteTodo(ctx context.Context, id string) (bool, error) {\n\tpanic(\"not implemented\")\n}")
+		require.NotContains(t, todo, "CreateTodo")
+		require.NotContains(t, todo, "\"strings\"")
+	})
+
+	t.Run("code that isn't generated any more is kept at the end", func(t *testing.T) {
+		todo := read("todo.resolvers.go")
+		warning := strings.Index(todo, "// !!! WARNING !!!")
+		require.True(t, warning > strings.Index(todo, "DeleteTodo"))
+		require.Contains(t, todo[warning:], "func (r *queryResolver) Todos(ctx context.Context) ([]string, error) {\n\treturn todos(), nil\n}")
+		require.Contains(t, todo[warning:], "func todos() []string {")
+	})
+
+	t.Run("the resolver type is not overwritten", func(t *testing.T) {
+		require.Contains(t, read("resolver.go"), "\tdb string\n")
+	})
+
+	t.Run("regenerating is stable", func(t *testing.T) {
+		user, todo := read("user.resolvers.go"), read("todo.resolvers.go")
+		generate("v2")
+		require.Equal(t, user, read("user.resolvers.go"))
+		require.Equal(t, todo, read("todo.resolvers.go"))
+	})
+
+	t.Run("files for removed schema files are removed once empty", func(t *testing.T) {
+		write("old.resolvers.go", "package out\n\ntype queryResolver struct{ *Resolver }\n\nfunc (r *Resolver) Query() QueryResolver {\n\treturn &queryResolver{r}\n}\n")
+		generate("v2")
+		_, err := os.Stat(filepath.Join(dir, "old.resolvers.go"))
+		require.True(t, os.IsNotExist(err))
+	})
+}
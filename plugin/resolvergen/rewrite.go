@@ -0,0 +1,157 @@
+package resolvergen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// existingResolvers is the code already in the resolver files, so regenerating them keeps the implementations and
+// anything else that was written there by hand.
+type existingResolvers struct {
+	filenames []string
+	imports   []*Import
+	impls     map[string]*implementation
+	decls     map[string][]*existingDecl
+	used      map[string]bool
+}
+
+type implementation struct {
+	comment string
+	body    string
+}
+
+// existingDecl is a top level declaration, keyed by receiver and method name for methods or by name for types
+type existingDecl struct {
+	key    string
+	source string
+}
+
+func readResolverFiles(pattern string) (*existingResolvers, error) {
+	r := &existingResolvers{
+		impls: map[string]*implementation{},
+		decls: map[string][]*existingDecl{},
+		used:  map[string]bool{},
+	}
+
+	filenames, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		if err := r.read(filename); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *existingResolvers) read(filename string) error {
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read existing resolvers")
+	}
+	r.filenames = append(r.filenames, filename)
+
+	text := func(from ast.Node, to ast.Node) string {
+		return string(src[fset.Position(from.Pos()).Offset:fset.Position(to.End()).Offset])
+	}
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		imp := &Import{Path: path}
+		if spec.Name != nil {
+			imp.Alias = spec.Name.Name
+		}
+		if imp.Alias == "_" || imp.Alias == "." || hasImport(r.imports, imp.Path, imp.name()) {
+			continue
+		}
+		r.imports = append(r.imports, imp)
+	}
+
+	for _, decl := range file.Decls {
+		var start ast.Node = decl
+		existing := &existingDecl{}
+
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				continue
+			}
+			if decl.Doc != nil {
+				start = decl.Doc
+			}
+			if decl.Tok == token.TYPE && len(decl.Specs) == 1 {
+				existing.key = decl.Specs[0].(*ast.TypeSpec).Name.Name
+			}
+		case *ast.FuncDecl:
+			if decl.Doc != nil {
+				start = decl.Doc
+			}
+			if decl.Recv != nil && len(decl.Recv.List) == 1 {
+				existing.key = receiverName(decl.Recv.List[0].Type) + "." + decl.Name.Name
+
+				impl := &implementation{body: text(decl.Body, decl.Body)}
+				if decl.Doc != nil {
+					impl.comment = text(decl.Doc, decl.Doc)
+				}
+				r.impls[existing.key] = impl
+			}
+		}
+
+		existing.source = text(start, decl)
+		r.decls[filename] = append(r.decls[filename], existing)
+	}
+
+	return nil
+}
+
+func receiverName(typ ast.Expr) string {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// generated marks a declaration as being generated, so it isn't kept as remaining source
+func (r *existingResolvers) generated(key string) {
+	r.used[key] = true
+}
+
+// implementation returns the existing implementation of a resolver method, if there is one
+func (r *existingResolvers) implementation(key string) *implementation {
+	r.used[key] = true
+	return r.impls[key]
+}
+
+// remaining returns the source of everything in filename that isn't generated any more
+func (r *existingResolvers) remaining(filename string) string {
+	var remaining []string
+	for _, decl := range r.decls[filename] {
+		if decl.key == "" || !r.used[decl.key] {
+			remaining = append(remaining, decl.source)
+		}
+	}
+	return strings.Join(remaining, "\n\n")
+}
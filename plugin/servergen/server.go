@@ -34,6 +34,11 @@ func (m *Plugin) GenerateCode(data *codegen.Data) error {
 			PackageName: "main",
 			Filename:    m.filename,
 			Data:        serverBuild,
+			Header:      data.Config.Header.String(),
+			TemplateDir: data.Config.TemplateDir,
+			LocalPrefix: data.Config.LocalPrefix,
+			SkipFormat:  data.Config.SkipFormat,
+			Files:       data.Config.Files,
 		})
 	}
 
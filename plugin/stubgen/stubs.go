@@ -2,7 +2,6 @@ package stubgen
 
 import (
 	"path/filepath"
-	"syscall"
 
 	"github.com/99designs/gqlgen/internal/code"
 
@@ -29,7 +28,7 @@ func (m *Plugin) Name() string {
 }
 
 func (m *Plugin) MutateConfig(cfg *config.Config) error {
-	_ = syscall.Unlink(m.filename)
+	cfg.Files.Unlink(m.filename)
 	return nil
 }
 
@@ -45,6 +44,11 @@ func (m *Plugin) GenerateCode(data *codegen.Data) error {
 			TypeName: m.typeName,
 		},
 		GeneratedHeader: true,
+		Header:          data.Config.Header.String(),
+		TemplateDir:     data.Config.TemplateDir,
+		LocalPrefix:     data.Config.LocalPrefix,
+		SkipFormat:      data.Config.SkipFormat,
+		Files:           data.Config.Files,
 	})
 }
 